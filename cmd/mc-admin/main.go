@@ -4,17 +4,39 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
+	"sebpok/mc-rcon-tui/internal/actions"
+	"sebpok/mc-rcon-tui/internal/history"
 	"sebpok/mc-rcon-tui/internal/rcon"
 	"sebpok/mc-rcon-tui/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// patterns collects a repeated flag.
+type patterns []string
+
+func (p *patterns) String() string { return strings.Join(*p, ", ") }
+
+func (p *patterns) Set(v string) error {
+	*p = append(*p, v)
+	return nil
+}
+
 func main() {
 	host := flag.String("host", "localhost", "RCON address")
 	port := flag.Int("port", 25575, "RCON port")
 	pass := flag.String("password", "", "RCON password")
+	world := flag.String("world", "", "path to the world directory, enables features reading server files")
+	favicon := flag.String("favicon", "auto", "server icon rendering: auto, blocks, kitty or off")
+	profile := flag.String("profile", "", "name the command history is saved under (default <host>:<port>)")
+	historySize := flag.Int("history-size", 1000, "number of commands kept in the history")
+	var exclude patterns
+	flag.Var(&exclude, "history-exclude", "regexp of commands never saved in the history, repeatable (default (?i)password)")
+	actionsPath := flag.String("actions", "", "JSON file with the player popup actions (default actions.json in the config directory)")
+	theme := flag.String("theme", "auto", "color theme: auto, dark or light")
+	palette := flag.String("palette", "", "JSON file overriding theme colors (default theme.json in the config directory)")
 	flag.Parse()
 
 	if *pass == "" {
@@ -24,6 +46,38 @@ func main() {
 
 	addr := fmt.Sprintf("%s:%d", *host, *port)
 
+	if *profile == "" {
+		*profile = addr
+	}
+	if len(exclude) == 0 {
+		exclude = patterns{"(?i)password"}
+	}
+	hist, err := history.Open(*profile, *historySize, exclude)
+	if hist == nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Println("command history not loaded:", err)
+	}
+	reasons, err := history.OpenFile("reasons", 50)
+	if err != nil {
+		fmt.Println("saved reasons not loaded:", err)
+	}
+
+	actionsConfig, err := actions.Load(*actionsPath)
+	if err != nil {
+		fmt.Println("player actions:", err)
+		os.Exit(1)
+	}
+
+	// before connecting, auto asks the terminal for its background
+	colors, err := ui.LoadTheme(*theme, *palette)
+	if err != nil {
+		fmt.Println("theme:", err)
+		os.Exit(1)
+	}
+
 	client, err := rcon.Connect(addr, *pass)
 	if err != nil {
 		fmt.Println("RCON connection error:", err)
@@ -31,8 +85,16 @@ func main() {
 	}
 	defer client.Close()
 
+	model := ui.NewModel(client, "localhost", 9)
+	model.SetFaviconMode(*favicon)
+	model.SetWorldDir(*world)
+	model.SetHistory(hist)
+	model.SetActions(actionsConfig)
+	model.SetReasons(reasons)
+	model.SetTheme(colors)
+
 	p := tea.NewProgram(
-		ui.NewModel(client, "localhost", 9),
+		model,
 		tea.WithAltScreen(),
 	)
 
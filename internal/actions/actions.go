@@ -0,0 +1,234 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"sebpok/mc-rcon-tui/internal/history"
+)
+
+// ParamType is the kind of value filled into a command template.
+type ParamType string
+
+const (
+	// the name of the player shown in the popup
+	ParamPlayer ParamType = "player"
+	ParamUUID   ParamType = "uuid"
+	// where the player stands, "x y z"
+	ParamPos ParamType = "pos"
+	// free text asked for
+	ParamText ParamType = "text"
+	// free text picked from Presets or the reasons given before
+	ParamReason ParamType = "reason"
+	// one of Choices, asked for
+	ParamChoice ParamType = "choice"
+)
+
+// Param is a placeholder of a command template, written as {name}.
+type Param struct {
+	Name  string    `json:"name"`
+	Type  ParamType `json:"type"`
+	Label string    `json:"label,omitempty"`
+
+	// text
+	Placeholder string `json:"placeholder,omitempty"`
+	// text and reason, may be left empty
+	Optional bool `json:"optional,omitempty"`
+
+	// reason
+	Presets []string `json:"presets,omitempty"`
+
+	// choice
+	Choices []string `json:"choices,omitempty"`
+}
+
+// Asked reports whether the value is asked for rather than taken from the
+// player.
+func (p Param) Asked() bool {
+	return p.Type == ParamText || p.Type == ParamReason || p.Type == ParamChoice
+}
+
+// Action is a command run on a player from the popup, or a menu of actions
+// when Actions is set.
+type Action struct {
+	Label string `json:"label"`
+	// e.g. "tp {player} {pos}"
+	Command string  `json:"command,omitempty"`
+	Params  []Param `json:"params,omitempty"`
+	// a color of the theme (red, green, yellow, dimmed) or a hex color
+	Color   string `json:"color,omitempty"`
+	Confirm bool   `json:"confirm,omitempty"`
+
+	Actions []Action `json:"actions,omitempty"`
+}
+
+// IsMenu reports whether the action only groups other actions.
+func (a Action) IsMenu() bool {
+	return len(a.Actions) > 0
+}
+
+// Config lists the actions of the player popup.
+type Config struct {
+	Actions []Action `json:"actions"`
+}
+
+// Default returns the actions used without a config file.
+func Default() Config {
+	return Config{Actions: []Action{
+		{
+			Label:   "kick",
+			Command: "kick {player} {reason}",
+			Params: []Param{{
+				Name: "reason", Type: ParamReason, Label: "Reason for kicking", Optional: true,
+				Presets: []string{"Spamming", "Offensive language", "AFK"},
+			}},
+			Color:   "dimmed",
+			Confirm: true,
+		},
+		{
+			Label:   "ban",
+			Command: "ban {player} {reason}",
+			Params: []Param{{
+				Name: "reason", Type: ParamReason, Label: "Reason for banning", Optional: true,
+				Presets: []string{"Griefing", "Cheating", "Harassment"},
+			}},
+			Color:   "red",
+			Confirm: true,
+		},
+		{Label: "op", Command: "op {player}", Color: "green", Confirm: true},
+		{Label: "deop", Command: "deop {player}", Color: "yellow", Confirm: true},
+	}}
+}
+
+// Load reads the actions from a JSON config file. With path empty
+// actions.json in history.ConfigDir is read if it exists, otherwise Default is
+// returned.
+func Load(path string) (Config, error) {
+	explicit := path != ""
+	if !explicit {
+		dir, err := history.ConfigDir()
+		if err != nil {
+			return Default(), nil
+		}
+		path = filepath.Join(dir, "actions.json")
+	}
+
+	data, err := os.ReadFile(path)
+	if !explicit && errors.Is(err, os.ErrNotExist) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(c.Actions) == 0 {
+		return Config{}, fmt.Errorf("%s: no actions defined", path)
+	}
+	for _, a := range c.Actions {
+		if err := a.validate(); err != nil {
+			return Config{}, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return c, nil
+}
+
+var rePlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+func (a Action) validate() error {
+	if a.Label == "" {
+		return errors.New("action without label")
+	}
+	if a.IsMenu() {
+		if a.Command != "" {
+			return fmt.Errorf("menu %q has a command", a.Label)
+		}
+		for _, sub := range a.Actions {
+			if err := sub.validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if a.Command == "" {
+		return fmt.Errorf("action %q has no command", a.Label)
+	}
+
+	for _, p := range a.Params {
+		switch p.Type {
+		case ParamPlayer, ParamUUID, ParamPos, ParamText, ParamReason:
+		case ParamChoice:
+			if len(p.Choices) == 0 {
+				return fmt.Errorf("action %q: parameter %q has no choices", a.Label, p.Name)
+			}
+		default:
+			return fmt.Errorf("action %q: parameter %q has unknown type %q", a.Label, p.Name, p.Type)
+		}
+	}
+	for _, match := range rePlaceholder.FindAllStringSubmatch(a.Command, -1) {
+		if _, ok := a.Param(match[1]); !ok {
+			return fmt.Errorf("action %q: unknown parameter {%s}", a.Label, match[1])
+		}
+	}
+	return nil
+}
+
+// Param returns the parameter of a placeholder. {player}, {uuid} and {pos}
+// need not be declared.
+func (a Action) Param(name string) (Param, bool) {
+	for _, p := range a.Params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	switch t := ParamType(name); t {
+	case ParamPlayer, ParamUUID, ParamPos:
+		return Param{Name: name, Type: t}, true
+	}
+	return Param{}, false
+}
+
+// Used returns the parameters of the placeholders in the command, in the
+// order they first appear.
+func (a Action) Used() []Param {
+	var params []Param
+	seen := map[string]bool{}
+	for _, match := range rePlaceholder.FindAllStringSubmatch(a.Command, -1) {
+		if p, ok := a.Param(match[1]); ok && !seen[p.Name] {
+			seen[p.Name] = true
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// Expand fills the placeholders of the command. An empty value drops one
+// of the spaces around its placeholder, everything else is kept as is.
+func (a Action) Expand(values map[string]string) string {
+	var out []byte
+	last := 0
+	for _, loc := range rePlaceholder.FindAllStringSubmatchIndex(a.Command, -1) {
+		out = append(out, a.Command[last:loc[0]]...)
+		last = loc[1]
+		if v := values[a.Command[loc[2]:loc[3]]]; v != "" {
+			out = append(out, v...)
+			continue
+		}
+
+		spaceAfter := last < len(a.Command) && a.Command[last] == ' '
+		switch {
+		case len(out) > 0 && out[len(out)-1] == ' ' && (spaceAfter || last == len(a.Command)):
+			out = out[:len(out)-1]
+		case len(out) == 0 && spaceAfter:
+			last++
+		}
+	}
+	return string(append(out, a.Command[last:]...))
+}
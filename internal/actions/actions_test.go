@@ -0,0 +1,23 @@
+package actions
+
+import "testing"
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		command string
+		values  map[string]string
+		want    string
+	}{
+		{"kick {player} {reason}", map[string]string{"player": "Steve", "reason": "too  many\tspaces"}, "kick Steve too  many\tspaces"},
+		{"kick {player} {reason}", map[string]string{"player": "Steve"}, "kick Steve"},
+		{"{reason} kick {player}", map[string]string{"player": "Steve"}, "kick Steve"},
+		{"msg {player} {a} {b} done", map[string]string{"player": "Steve"}, "msg Steve done"},
+		{`tellraw {player} {"text":"a  b"}`, map[string]string{"player": "Steve"}, `tellraw Steve {"text":"a  b"}`},
+	}
+	for _, tt := range tests {
+		a := Action{Command: tt.command}
+		if got := a.Expand(tt.values); got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
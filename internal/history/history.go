@@ -0,0 +1,195 @@
+package history
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// History keeps the commands typed in the cmds tab, oldest first. Commands
+// are stored once, using one again moves it to the end.
+type History struct {
+	// empty for a history kept only in memory
+	path    string
+	entries []string
+	max     int
+	exclude []*regexp.Regexp
+}
+
+// New returns a history kept in memory. Commands matching one of the exclude
+// patterns are never recorded.
+func New(max int, exclude []string) (*History, error) {
+	h := &History{max: max}
+	for _, p := range exclude {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid history exclude pattern %q: %w", p, err)
+		}
+		h.exclude = append(h.exclude, re)
+	}
+	return h, nil
+}
+
+// Open loads the history of a server profile from the state directory, it
+// is saved there after every command. When the file cannot be read the
+// returned history is still usable but only kept in memory.
+func Open(profile string, max int, exclude []string) (*History, error) {
+	h, err := New(max, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := StateDir()
+	if err != nil {
+		return h, err
+	}
+	return h, h.load(filepath.Join(dir, "history", ProfileName(profile)))
+}
+
+// OpenFile loads a list kept in the state directory under name, e.g. the
+// reasons given for kicks and bans, see Open.
+func OpenFile(name string, max int) (*History, error) {
+	h := &History{max: max}
+	dir, err := StateDir()
+	if err != nil {
+		return h, err
+	}
+	return h, h.load(filepath.Join(dir, name))
+}
+
+func (h *History) load(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		h.path = path
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		h.add(s.Text())
+	}
+	if err := s.Err(); err != nil {
+		h.entries = nil
+		return err
+	}
+	h.path = path
+	return nil
+}
+
+// StateDir returns the directory for files kept between runs,
+// $XDG_STATE_HOME/mc-admin or ~/.local/state/mc-admin.
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "mc-admin"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "mc-admin"), nil
+}
+
+// ConfigDir returns the directory of the config files,
+// $XDG_CONFIG_HOME/mc-admin or ~/.config/mc-admin.
+func ConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mc-admin"), nil
+}
+
+var reUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ProfileName turns a profile, e.g. "host:port", into a file name.
+func ProfileName(profile string) string {
+	name := strings.Trim(reUnsafe.ReplaceAllString(profile, "_"), "._")
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// Excluded reports whether cmd matches one of the exclude patterns.
+func (h *History) Excluded(cmd string) bool {
+	for _, re := range h.exclude {
+		if re.MatchString(cmd) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *History) add(cmd string) bool {
+	cmd = strings.TrimSpace(cmd)
+	if cmd == "" || h.Excluded(cmd) {
+		return false
+	}
+
+	for i, e := range h.entries {
+		if e == cmd {
+			h.entries = append(h.entries[:i], h.entries[i+1:]...)
+			break
+		}
+	}
+	h.entries = append(h.entries, cmd)
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+	return true
+}
+
+// Add records cmd and saves the history.
+func (h *History) Add(cmd string) error {
+	if !h.add(cmd) {
+		return nil
+	}
+	return h.save()
+}
+
+func (h *History) save() error {
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return err
+	}
+
+	// commands may contain secrets the patterns missed, keep the file private
+	tmp := h.path + ".tmp"
+	content := strings.Join(h.entries, "\n") + "\n"
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
+
+// Len returns the number of recorded commands.
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// At returns the i-th command, 0 being the oldest.
+func (h *History) At(i int) string {
+	return h.entries[i]
+}
+
+// Search returns the index of the newest command before index from that
+// contains query, ignoring case, or -1.
+func (h *History) Search(query string, from int) int {
+	q := strings.ToLower(query)
+	for i := min(from, len(h.entries)) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(h.entries[i]), q) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,134 @@
+package mc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Ban struct {
+	Target  string // player name or IP address
+	IP      bool
+	Source  string
+	Reason  string
+	Created time.Time
+	Expires string
+
+	// the target may have run into the reason of the previous entry, see
+	// ParseBanlist
+	Ambiguous bool
+}
+
+var (
+	reBanPlayer = regexp.MustCompile(`([A-Za-z0-9_]{1,16}) was banned by ([^:]+?): `)
+	reBanIP     = regexp.MustCompile(`(\d{1,3}(?:\.\d{1,3}){3}|[0-9a-fA-F]*:[0-9a-fA-F:]+) was banned by ([^:]+?): `)
+)
+
+// ParseBanlist parses `banlist players` or `banlist ips`. RCON may join the
+// entries without newlines, so entries are split on the "was banned by"
+// pattern and the reason spans up to the next entry. When a reason ends in a
+// word, "griefingBob was banned by", the target cannot be told apart from it
+// and the entry is marked Ambiguous; ReadBans has no such problem.
+func ParseBanlist(resp string, ips bool) ([]Ban, error) {
+	clean := RemoveColorCodes(resp)
+
+	if strings.Contains(clean, "There are no bans") {
+		return nil, nil
+	}
+
+	i := strings.Index(clean, "ban(s):")
+	if i < 0 {
+		return nil, responseError(resp, "invalid banlist output")
+	}
+	body := clean[i+len("ban(s):"):]
+
+	re := reBanPlayer
+	if ips {
+		re = reBanIP
+	}
+
+	idx := re.FindAllStringSubmatchIndex(body, -1)
+
+	var bans []Ban
+	for n, m := range idx {
+		end := len(body)
+		if n+1 < len(idx) {
+			end = idx[n+1][0]
+		}
+		bans = append(bans, Ban{
+			Target:    body[m[2]:m[3]],
+			IP:        ips,
+			Source:    strings.TrimSpace(body[m[4]:m[5]]),
+			Reason:    strings.TrimSpace(body[m[1]:end]),
+			Ambiguous: n > 0 && !endsEntry(body[:m[2]], ips),
+		})
+	}
+
+	return bans, nil
+}
+
+// endsEntry reports whether the text before a ban target ends so that the
+// target cannot continue it, e.g. "Banned by an operator." does for names
+// but not for IP addresses.
+func endsEntry(before string, ips bool) bool {
+	r, _ := utf8.DecodeLastRuneInString(before)
+	switch {
+	case r == '\n':
+		return true
+	case r == utf8.RuneError, unicode.IsSpace(r), unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		return false
+	case ips && (r == '.' || r == ':'):
+		return false
+	}
+	return true
+}
+
+type banEntry struct {
+	Name    string `json:"name"`
+	IP      string `json:"ip"`
+	Created string `json:"created"`
+	Source  string `json:"source"`
+	Expires string `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+// ReadBans reads banned-players.json or banned-ips.json from the server
+// directory. Unlike `banlist` the files also contain dates.
+func ReadBans(serverDir string, ips bool) ([]Ban, error) {
+	name := "banned-players.json"
+	if ips {
+		name = "banned-ips.json"
+	}
+
+	data, err := os.ReadFile(filepath.Join(serverDir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []banEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	bans := make([]Ban, 0, len(entries))
+	for _, e := range entries {
+		b := Ban{
+			Target:  e.Name,
+			IP:      ips,
+			Source:  e.Source,
+			Reason:  e.Reason,
+			Expires: e.Expires,
+		}
+		if ips {
+			b.Target = e.IP
+		}
+		b.Created, _ = time.Parse("2006-01-02 15:04:05 -0700", e.Created)
+		bans = append(bans, b)
+	}
+	return bans, nil
+}
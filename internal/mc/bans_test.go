@@ -0,0 +1,75 @@
+package mc
+
+import "testing"
+
+func TestParseBanlist(t *testing.T) {
+	tests := []struct {
+		name string
+		resp string
+		ips  bool
+		want []Ban
+	}{
+		{
+			name: "newlines",
+			resp: "There are 2 ban(s):\nSteve was banned by Server: griefing\nAlex was banned by Admin: Banned by an operator.",
+			want: []Ban{
+				{Target: "Steve", Source: "Server", Reason: "griefing"},
+				{Target: "Alex", Source: "Admin", Reason: "Banned by an operator."},
+			},
+		},
+		{
+			name: "joined after punctuation",
+			resp: "There are 2 ban(s):Steve was banned by Server: Banned by an operator.Alex was banned by Admin: x",
+			want: []Ban{
+				{Target: "Steve", Source: "Server", Reason: "Banned by an operator."},
+				{Target: "Alex", Source: "Admin", Reason: "x"},
+			},
+		},
+		{
+			name: "joined after a word",
+			resp: "There are 2 ban(s):Steve was banned by Server: griefingBob was banned by Admin: spam",
+			want: []Ban{
+				{Target: "Steve", Source: "Server", Reason: ""},
+				{Target: "griefingBob", Source: "Admin", Reason: "spam", Ambiguous: true},
+			},
+		},
+		{
+			// "griefing" may be the reason or part of the name
+			name: "joined after a one word reason",
+			resp: "There are 2 ban(s):Steve was banned by Server: griefing was banned by Admin: spam",
+			want: []Ban{
+				{Target: "Steve", Source: "Server", Reason: ""},
+				{Target: "griefing", Source: "Admin", Reason: "spam", Ambiguous: true},
+			},
+		},
+		{
+			name: "ip after a sentence",
+			resp: "There are 2 ban(s):1.2.3.4 was banned by Server: version 1.5.6.7.8 was banned by Admin: spam",
+			ips:  true,
+			want: []Ban{
+				{Target: "1.2.3.4", IP: true, Source: "Server", Reason: "version 1."},
+				{Target: "5.6.7.8", IP: true, Source: "Admin", Reason: "spam", Ambiguous: true},
+			},
+		},
+		{
+			name: "none",
+			resp: "There are no bans",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBanlist(tt.resp, tt.ips)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d bans %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ban %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package mc
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Tnze/go-mc/nbt"
+)
+
+type Bossbar struct {
+	ID      string // e.g. "minecraft:event"
+	Name    string
+	Value   int
+	Max     int
+	Color   string
+	Style   string
+	Visible bool
+	Players []string
+}
+
+// BossbarColors and BossbarStyles are the values accepted by `bossbar set`.
+var (
+	BossbarColors = []string{"blue", "green", "pink", "purple", "red", "white", "yellow"}
+	BossbarStyles = []string{"progress", "notched_6", "notched_10", "notched_12", "notched_20"}
+)
+
+var reBossbarList = regexp.MustCompile(`There (?:are|is) \d+ custom bossbars? ?(?:\(s\))? active:`)
+
+// ParseBossbarList parses `bossbar list`. The server lists the display
+// names, not the IDs.
+func ParseBossbarList(resp string) ([]string, error) {
+	if err := ClassifyResponse(resp); err != nil {
+		return nil, err
+	}
+
+	clean := RemoveColorCodes(resp)
+	if strings.Contains(clean, "There are no custom bossbars active") {
+		return nil, nil
+	}
+
+	loc := reBossbarList.FindStringIndex(clean)
+	if loc == nil {
+		return nil, responseError(resp, "invalid bossbar list output")
+	}
+
+	var names []string
+	for _, m := range reBracketed.FindAllStringSubmatch(clean[loc[1]:], -1) {
+		names = append(names, m[1])
+	}
+	return names, nil
+}
+
+var (
+	reBossbarNumber = regexp.MustCompile(`has a (?:value|maximum) of (-?\d+)`)
+	reBossbarOnline = regexp.MustCompile(`currently online: (.*)$`)
+)
+
+// ParseBossbarNumber parses `bossbar get <id> value|max`.
+func ParseBossbarNumber(resp string) (int, error) {
+	m := reBossbarNumber.FindStringSubmatch(RemoveColorCodes(resp))
+	if m == nil {
+		return 0, responseError(resp, "invalid bossbar output")
+	}
+	return strconv.Atoi(m[1])
+}
+
+// ParseBossbarVisible parses `bossbar get <id> visible`.
+func ParseBossbarVisible(resp string) (bool, error) {
+	clean := RemoveColorCodes(resp)
+	switch {
+	case strings.Contains(clean, "is currently shown"):
+		return true, nil
+	case strings.Contains(clean, "is currently hidden"):
+		return false, nil
+	}
+	return false, responseError(resp, "invalid bossbar visibility output")
+}
+
+// ParseBossbarPlayers parses `bossbar get <id> players`.
+func ParseBossbarPlayers(resp string) ([]string, error) {
+	clean := strings.TrimSpace(RemoveColorCodes(resp))
+	if strings.Contains(clean, "has no players currently online") {
+		return nil, nil
+	}
+
+	m := reBossbarOnline.FindStringSubmatch(clean)
+	if m == nil {
+		return nil, responseError(resp, "invalid bossbar players output")
+	}
+
+	var players []string
+	for _, p := range strings.Split(m[1], ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			players = append(players, p)
+		}
+	}
+	return players, nil
+}
+
+type bossEvent struct {
+	Name    nbt.RawMessage
+	Value   int32
+	Max     int32
+	Color   string
+	Overlay string
+	Visible byte
+}
+
+// ReadBossbars reads the custom bossbars saved in level.dat. It is the only
+// way to learn their IDs, color and style.
+func ReadBossbars(worldDir string) ([]Bossbar, error) {
+	var f levelFile
+	if err := readGzipNBT(filepath.Join(worldDir, "level.dat"), &f); err != nil {
+		return nil, err
+	}
+
+	var bars []Bossbar
+	for id, e := range f.Data.CustomBossEvents {
+		bars = append(bars, Bossbar{
+			ID:      id,
+			Name:    componentText(e.Name),
+			Value:   int(e.Value),
+			Max:     int(e.Max),
+			Color:   e.Color,
+			Style:   e.Overlay,
+			Visible: e.Visible != 0,
+		})
+	}
+	sort.Slice(bars, func(i, j int) bool { return bars[i].ID < bars[j].ID })
+	return bars, nil
+}
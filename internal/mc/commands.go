@@ -0,0 +1,372 @@
+package mc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ArgKind is the kind of value an argument takes, used to suggest values.
+type ArgKind int
+
+const (
+	ArgOther ArgKind = iota
+	ArgEntity
+	ArgItem
+	ArgBlock
+	// coordinates, x y z
+	ArgPos
+	// two coordinates or angles
+	ArgPos2
+	// the rest of the line, e.g. a message
+	ArgText
+)
+
+// Words returns the number of space separated words the argument takes,
+// 0 for the rest of the line.
+func (k ArgKind) Words() int {
+	switch k {
+	case ArgPos:
+		return 3
+	case ArgPos2:
+		return 2
+	case ArgText:
+		return 0
+	}
+	return 1
+}
+
+// CommandNode is a literal or an argument of the command tree.
+type CommandNode struct {
+	Name    string
+	Literal bool
+	Kind    ArgKind
+
+	Children []*CommandNode
+
+	// the node continues at another command, "/" for the root, e.g.
+	// `execute run`
+	Redirect string
+}
+
+// CommandTree holds the commands known to the server.
+type CommandTree struct {
+	Root *CommandNode
+	// true when built from the commands.json report, which has every node,
+	// false when built from `help` output
+	Complete bool
+}
+
+func NewCommandTree() *CommandTree {
+	return &CommandTree{Root: &CommandNode{}}
+}
+
+func (n *CommandNode) child(name string, literal bool) *CommandNode {
+	for _, c := range n.Children {
+		if c.Name == name && c.Literal == literal {
+			return c
+		}
+	}
+	c := &CommandNode{Name: name, Literal: literal}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// Command returns the node of a command, or nil.
+func (t *CommandTree) Command(name string) *CommandNode {
+	for _, c := range t.Root.Children {
+		if c.Literal && c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// argKindByName guesses the kind of an argument from its name, `help` does
+// not print the argument types.
+func argKindByName(command string, name string) ArgKind {
+	// the player or entity teleported to, <location> are the coordinates
+	if name == "destination" && (command == "tp" || command == "teleport") {
+		return ArgEntity
+	}
+	switch name {
+	case "targets", "target", "player", "players", "entity", "source",
+		"destination_entity", "victim", "attacker":
+		return ArgEntity
+	case "item":
+		return ArgItem
+	case "block":
+		return ArgBlock
+	case "pos", "location", "destination", "from", "to", "begin", "end",
+		"center", "targetPos":
+		return ArgPos
+	case "rotation", "facing", "chunkPos":
+		return ArgPos2
+	case "message", "action", "reason", "command":
+		return ArgText
+	}
+	return ArgOther
+}
+
+// AddUsage adds the usages printed by `help` or `help <command>`, e.g.
+// "/time set (day|night|<time>)". Over RCON vanilla joins them without a
+// newline, "/advancement (grant|revoke)/ban <targets> [<reason>]", so they
+// are split at every "/" outside of brackets. Other lines are skipped.
+func (t *CommandTree) AddUsage(resp string) {
+	for _, line := range strings.Split(RemoveColorCodes(resp), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "/") {
+			continue
+		}
+		// Bukkit help pages list "/name: description", the description may
+		// contain slashes
+		if first, _, _ := strings.Cut(line[1:], " "); strings.HasSuffix(first, ":") {
+			t.Root.child(strings.TrimSuffix(first, ":"), true)
+			continue
+		}
+		for _, usage := range splitUsage(line, '/') {
+			if tokens := splitUsage(usage, ' '); len(tokens) > 0 {
+				addUsage(t.Root, tokens[0], tokens)
+			}
+		}
+	}
+}
+
+func addUsage(n *CommandNode, command string, tokens []string) {
+	if len(tokens) == 0 {
+		return
+	}
+	tok, rest := tokens[0], tokens[1:]
+
+	switch {
+	case tok == "...":
+		n.Redirect = "/"
+	case tok == "->":
+		if len(rest) > 0 {
+			n.Redirect = rest[0]
+		}
+	case strings.HasPrefix(tok, "[") && strings.HasSuffix(tok, "]"):
+		inner := splitUsage(tok[1:len(tok)-1], ' ')
+		addUsage(n, command, append(inner, rest...))
+		addUsage(n, command, rest)
+	case strings.HasPrefix(tok, "(") && strings.HasSuffix(tok, ")"):
+		for _, alt := range splitUsage(tok[1:len(tok)-1], '|') {
+			addUsage(n, command, append(splitUsage(alt, ' '), rest...))
+		}
+	case strings.HasPrefix(tok, "<") && strings.HasSuffix(tok, ">"):
+		c := n.child(tok[1:len(tok)-1], false)
+		c.Kind = argKindByName(command, c.Name)
+		addUsage(c, command, rest)
+	default:
+		addUsage(n.child(tok, true), command, rest)
+	}
+}
+
+// splitUsage splits s at sep outside of brackets.
+func splitUsage(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '<':
+			depth++
+		case ')', ']', '>':
+			// the arrow of "-> execute" closes nothing
+			if !(s[i] == '>' && i > 0 && s[i-1] == '-') {
+				depth--
+			}
+		case sep:
+			if depth == 0 {
+				if p := strings.TrimSpace(s[start:i]); p != "" {
+					parts = append(parts, p)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if p := strings.TrimSpace(s[start:]); p != "" {
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+type reportNode struct {
+	Type       string                 `json:"type"`
+	Parser     string                 `json:"parser"`
+	Properties map[string]any         `json:"properties"`
+	Children   map[string]*reportNode `json:"children"`
+	Redirect   *[]string              `json:"redirect"`
+}
+
+// ReadCommandsReport reads generated/reports/commands.json, written by the
+// server's data generator (`java -DbundlerMainClass=net.minecraft.data.Main
+// -jar server.jar --reports`).
+func ReadCommandsReport(serverDir string) (*CommandTree, error) {
+	data, err := os.ReadFile(filepath.Join(serverDir, "generated", "reports", "commands.json"))
+	if err != nil {
+		return nil, err
+	}
+	var root reportNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	t := &CommandTree{Root: &CommandNode{}, Complete: true}
+	addReportChildren(t.Root, &root)
+	return t, nil
+}
+
+func addReportChildren(n *CommandNode, r *reportNode) {
+	names := make([]string, 0, len(r.Children))
+	for name := range r.Children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rc := r.Children[name]
+		c := &CommandNode{Name: name, Literal: rc.Type == "literal"}
+		if !c.Literal {
+			c.Kind = argKindByParser(rc.Parser, rc.Properties)
+		}
+		if rc.Redirect != nil {
+			c.Redirect = "/"
+			if len(*rc.Redirect) > 0 {
+				c.Redirect = (*rc.Redirect)[0]
+			}
+		}
+		addReportChildren(c, rc)
+		n.Children = append(n.Children, c)
+	}
+}
+
+func argKindByParser(parser string, properties map[string]any) ArgKind {
+	switch parser {
+	case "minecraft:entity", "minecraft:game_profile", "minecraft:score_holder":
+		return ArgEntity
+	case "minecraft:item_stack", "minecraft:item_predicate":
+		return ArgItem
+	case "minecraft:block_state", "minecraft:block_predicate":
+		return ArgBlock
+	case "minecraft:block_pos", "minecraft:vec3":
+		return ArgPos
+	case "minecraft:vec2", "minecraft:column_pos", "minecraft:rotation":
+		return ArgPos2
+	case "minecraft:message":
+		return ArgText
+	case "brigadier:string":
+		if properties["type"] == "greedy" {
+			return ArgText
+		}
+	}
+	return ArgOther
+}
+
+// ReadRegistryIDs reads the item and block IDs from the registries.json
+// report, see ReadCommandsReport.
+func ReadRegistryIDs(serverDir string) (items []string, blocks []string, err error) {
+	data, err := os.ReadFile(filepath.Join(serverDir, "generated", "reports", "registries.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var registries map[string]struct {
+		Entries map[string]json.RawMessage `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &registries); err != nil {
+		return nil, nil, err
+	}
+
+	ids := func(registry string) []string {
+		var out []string
+		for id := range registries[registry].Entries {
+			out = append(out, strings.TrimPrefix(id, "minecraft:"))
+		}
+		sort.Strings(out)
+		return out
+	}
+	return ids("minecraft:item"), ids("minecraft:block"), nil
+}
+
+// CommandWords splits a command line into words. Selector arguments and
+// quoted strings may contain spaces, they stay one word. The last word is
+// empty when the line ends with a space.
+func CommandWords(line string) []string {
+	var words []string
+	var b strings.Builder
+	depth, quote := 0, byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote && line[i-1] != '\\' {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ' ' && depth <= 0:
+			words = append(words, b.String())
+			b.Reset()
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return append(words, b.String())
+}
+
+// Next returns the nodes that may follow the given complete words, nil when
+// they do not match the tree or end in free text. A word matching no literal
+// may be any of the arguments, e.g. `tp <targets>` continues with both
+// <destination> and <location>, the children of all of them are merged.
+func (t *CommandTree) Next(words []string) []*CommandNode {
+	var out []*CommandNode
+	seen := map[*CommandNode]bool{}
+	t.next(t.Root, words, func(c *CommandNode) {
+		if !seen[c] {
+			seen[c] = true
+			out = append(out, c)
+		}
+	})
+	return out
+}
+
+func (t *CommandTree) next(n *CommandNode, words []string, add func(c *CommandNode)) {
+	if len(words) == 0 {
+		for _, c := range n.Children {
+			add(c)
+		}
+		return
+	}
+
+	for _, c := range n.Children {
+		if c.Literal && c.Name == words[0] {
+			t.next(t.resolve(c), words[1:], add)
+			return
+		}
+	}
+	for _, c := range n.Children {
+		// free text ends the suggestions, and there are none while the
+		// coordinates are typed
+		if k := c.Kind.Words(); !c.Literal && k > 0 && k <= len(words) {
+			t.next(t.resolve(c), words[k:], add)
+		}
+	}
+}
+
+func (t *CommandTree) resolve(n *CommandNode) *CommandNode {
+	switch n.Redirect {
+	case "":
+		return n
+	case "/":
+		return t.Root
+	}
+	if c := t.Command(n.Redirect); c != nil {
+		return c
+	}
+	return n
+}
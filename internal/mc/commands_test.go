@@ -0,0 +1,91 @@
+package mc
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func commandNames(t *CommandTree) []string {
+	var names []string
+	for _, c := range t.Root.Children {
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func childNames(nodes []*CommandNode) []string {
+	var names []string
+	for _, n := range nodes {
+		names = append(names, n.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestAddUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		resp string
+	}{
+		{"joined", "/advancement (grant|revoke)/ban <targets> [<reason>]/give <targets> <item> [<count>]/seed/time (add|query|set)"},
+		{"newlines", "/advancement (grant|revoke)\n/ban <targets> [<reason>]\n/give <targets> <item> [<count>]\n/seed\n/time (add|query|set)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewCommandTree()
+			tree.AddUsage(tt.resp)
+
+			want := []string{"advancement", "ban", "give", "seed", "time"}
+			if got := commandNames(tree); !reflect.DeepEqual(got, want) {
+				t.Fatalf("commands = %v, want %v", got, want)
+			}
+			if got, want := childNames(tree.Next([]string{"time"})), []string{"add", "query", "set"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("time children = %v, want %v", got, want)
+			}
+			next := tree.Next([]string{"give", "Steve"})
+			if len(next) != 1 || next[0].Kind != ArgItem {
+				t.Errorf("give <targets> children = %v, want <item>", childNames(next))
+			}
+		})
+	}
+}
+
+func TestAddUsageCommandHelp(t *testing.T) {
+	tree := NewCommandTree()
+	tree.AddUsage("/time add <time>/time query (daytime|gametime|day)/time set (day|night|noon|midnight|<time>)")
+
+	if got, want := childNames(tree.Next([]string{"time"})), []string{"add", "query", "set"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("time children = %v, want %v", got, want)
+	}
+	if got, want := childNames(tree.Next([]string{"time", "set"})), []string{"day", "midnight", "night", "noon", "time"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("time set children = %v, want %v", got, want)
+	}
+}
+
+func TestAddUsageBukkit(t *testing.T) {
+	tree := NewCommandTree()
+	tree.AddUsage("/plugins: Gets a list/overview of plugins\n/tps: Shows the server TPS")
+
+	if got, want := commandNames(tree), []string{"plugins", "tps"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("commands = %v, want %v", got, want)
+	}
+}
+
+func TestNextArgumentAlternatives(t *testing.T) {
+	tree := NewCommandTree()
+	tree.AddUsage("/teleport (<location>|<destination>|<targets> (<location>|<destination>))")
+
+	if got, want := childNames(tree.Next([]string{"teleport"})), []string{"destination", "location", "targets"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("teleport children = %v, want %v", got, want)
+	}
+	// Steve may be the <destination> or the <targets>, only the latter
+	// continues
+	if got, want := childNames(tree.Next([]string{"teleport", "Steve"})), []string{"destination", "location"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("teleport Steve children = %v, want %v", got, want)
+	}
+	if got := tree.Next([]string{"teleport", "Steve", "Alex"}); len(got) != 0 {
+		t.Errorf("teleport Steve Alex children = %v, want none", childNames(got))
+	}
+}
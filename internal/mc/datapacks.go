@@ -0,0 +1,130 @@
+package mc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Datapack struct {
+	Name    string // e.g. "vanilla" or "file/mypack.zip"
+	Source  string // e.g. "built-in", "world"
+	Enabled bool
+}
+
+var (
+	reDatapackCount = regexp.MustCompile(`There are (\d+) data pack(?:\(s\)|s)? (?:enabled|available):`)
+	reDatapackEntry = regexp.MustCompile(`\[([^\]]+)\]`)
+)
+
+// ParseDatapackList parses `datapack list enabled` or `datapack list
+// available`. Entries are written as "[name (source)]", enabled packs are
+// listed in load order.
+func ParseDatapackList(resp string, enabled bool) ([]Datapack, error) {
+	if err := ClassifyResponse(resp); err != nil {
+		return nil, err
+	}
+
+	clean := RemoveColorCodes(resp)
+	if strings.Contains(clean, "There are no") {
+		return nil, nil
+	}
+
+	loc := reDatapackCount.FindStringIndex(clean)
+	if loc == nil {
+		return nil, responseError(resp, "invalid datapack list output")
+	}
+
+	var packs []Datapack
+	for _, m := range reDatapackEntry.FindAllStringSubmatch(clean[loc[1]:], -1) {
+		p := Datapack{Name: m[1], Enabled: enabled}
+		if i := strings.LastIndex(m[1], " ("); i >= 0 && strings.HasSuffix(m[1], ")") {
+			p.Name = m[1][:i]
+			p.Source = m[1][i+2 : len(m[1])-1]
+		}
+		packs = append(packs, p)
+	}
+	return packs, nil
+}
+
+// DatapackArg quotes a pack name for the datapack command, names such as
+// "file/pack.zip" are not valid unquoted strings.
+func DatapackArg(name string) string {
+	return strconv.Quote(name)
+}
+
+// InstallDatapack copies a datapack zip or folder into the world's datapacks
+// directory and returns the name the server will list it under.
+func InstallDatapack(worldDir string, src string) (string, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+
+	base := filepath.Base(filepath.Clean(src))
+	if !info.IsDir() && !strings.EqualFold(filepath.Ext(base), ".zip") {
+		return "", fmt.Errorf("%s is not a zip file or a folder", src)
+	}
+	if info.IsDir() {
+		if _, err := os.Stat(filepath.Join(src, "pack.mcmeta")); err != nil {
+			return "", fmt.Errorf("%s has no pack.mcmeta", src)
+		}
+	}
+
+	dst := filepath.Join(worldDir, "datapacks", base)
+	if _, err := os.Stat(dst); err == nil {
+		return "", fmt.Errorf("%s is already installed", base)
+	}
+	if err := os.MkdirAll(filepath.Join(worldDir, "datapacks"), 0o755); err != nil {
+		return "", err
+	}
+
+	if info.IsDir() {
+		err = copyDir(src, dst)
+	} else {
+		err = copyFile(src, dst)
+	}
+	if err != nil {
+		return "", err
+	}
+	return "file/" + base, nil
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,82 @@
+package mc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimensions are the vanilla dimensions counted by the entity census.
+var Dimensions = []string{"overworld", "the_nether", "the_end"}
+
+// EntityTypes lists the vanilla entity types worth counting when hunting
+// lag. Players are left out on purpose so cleanup never targets them. Types
+// missing from older servers fail the test and count as 0.
+var EntityTypes = []string{
+	// drops and projectiles
+	"item", "experience_orb", "arrow", "spectral_arrow", "trident",
+	"snowball", "egg", "ender_pearl", "fireball", "small_fireball",
+	"wither_skull", "firework_rocket", "potion", "experience_bottle",
+	"llama_spit", "shulker_bullet", "wind_charge",
+
+	// blocks and vehicles
+	"falling_block", "tnt", "minecart", "chest_minecart", "hopper_minecart",
+	"furnace_minecart", "tnt_minecart", "command_block_minecart",
+	"spawner_minecart", "boat", "chest_boat", "oak_boat", "spruce_boat",
+	"birch_boat", "jungle_boat", "acacia_boat", "cherry_boat",
+	"dark_oak_boat", "mangrove_boat", "bamboo_raft",
+
+	// decoration
+	"armor_stand", "item_frame", "glow_item_frame", "painting",
+	"leash_knot", "end_crystal", "marker", "area_effect_cloud",
+	"text_display", "item_display", "block_display", "interaction",
+
+	// passive
+	"allay", "armadillo", "axolotl", "bat", "bee", "camel", "cat", "chicken",
+	"cod", "cow", "donkey", "fox", "frog", "glow_squid", "goat", "horse",
+	"llama", "mooshroom", "mule", "ocelot", "panda", "parrot", "pig",
+	"polar_bear", "pufferfish", "rabbit", "salmon", "sheep", "sniffer",
+	"squid", "strider", "tadpole", "trader_llama", "tropical_fish",
+	"turtle", "villager", "wandering_trader", "wolf", "dolphin",
+	"skeleton_horse", "zombie_horse", "iron_golem", "snow_golem",
+
+	// hostile
+	"blaze", "bogged", "breeze", "cave_spider", "creaking", "creeper",
+	"drowned", "elder_guardian", "ender_dragon", "enderman", "endermite",
+	"evoker", "ghast", "guardian", "hoglin", "husk", "magma_cube",
+	"phantom", "piglin", "piglin_brute", "pillager", "ravager", "shulker",
+	"silverfish", "skeleton", "slime", "spider", "stray", "vex",
+	"vindicator", "warden", "witch", "wither", "wither_skeleton",
+	"zoglin", "zombie", "zombie_villager", "zombified_piglin",
+}
+
+// CountEntitiesCommand returns the command counting loaded entities matching
+// the selector arguments in a dimension. distance=0.. limits @e to the
+// dimension the command runs in.
+func CountEntitiesCommand(dimension string, args string) string {
+	sel := "distance=0.."
+	if args != "" {
+		sel += "," + args
+	}
+	return fmt.Sprintf("execute in minecraft:%s if entity @e[%s]", dimension, sel)
+}
+
+// ValidateCleanupSelector checks selector arguments used for a kill so that
+// they can never match players.
+func ValidateCleanupSelector(args string) error {
+	hasType := false
+	for _, part := range strings.Split(args, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || strings.TrimSpace(k) != "type" {
+			continue
+		}
+		v = strings.TrimPrefix(strings.TrimSpace(v), "minecraft:")
+		if v == "player" || strings.HasPrefix(v, "!") || strings.HasPrefix(v, "#") {
+			return fmt.Errorf("type=%s could match players", v)
+		}
+		hasType = true
+	}
+	if !hasType {
+		return fmt.Errorf("selector needs an explicit type=, got %q", args)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package mc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCommand   = errors.New("unknown or incomplete command")
+	ErrNoSuchPlayer     = errors.New("no such player or entity")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrSyntax           = errors.New("command syntax error")
+)
+
+// SyntaxError is a Brigadier parse error. Position is -1 when the server
+// only reported the context marker without an absolute cursor position.
+type SyntaxError struct {
+	Message  string
+	Context  string
+	Position int
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Position >= 0 {
+		return fmt.Sprintf("%s at position %d: %s<--[HERE]", e.Message, e.Position, e.Context)
+	}
+	if e.Context != "" {
+		return fmt.Sprintf("%s: %s<--[HERE]", e.Message, e.Context)
+	}
+	return e.Message
+}
+
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+var (
+	unknownCommandMarkers = []string{
+		"Unknown or incomplete command",
+		"Unknown command",
+	}
+	noSuchPlayerMarkers = []string{
+		"No entity was found",
+		"No player was found",
+		"That player does not exist",
+		"Player not found",
+		"No targets matched selector",
+	}
+	permissionMarkers = []string{
+		"You do not have permission",
+		"I'm sorry, but you do not have permission",
+		"Insufficient permissions",
+	}
+)
+
+var reSyntaxPosition = regexp.MustCompile(`^(.*?) at position (\d+): (.*?)(?:<--\[HERE\])?$`)
+
+// ClassifyResponse maps a raw RCON response to one of the typed errors above.
+// It returns nil when the response does not look like an error.
+func ClassifyResponse(resp string) error {
+	clean := strings.TrimSpace(RemoveColorCodes(resp))
+	if clean == "" {
+		return nil
+	}
+
+	for _, m := range permissionMarkers {
+		if strings.Contains(clean, m) {
+			return fmt.Errorf("%w: %s", ErrPermissionDenied, firstLine(clean))
+		}
+	}
+
+	for _, m := range unknownCommandMarkers {
+		if strings.HasPrefix(clean, m) {
+			return fmt.Errorf("%w: %s", ErrUnknownCommand, hereContext(clean))
+		}
+	}
+
+	for _, m := range noSuchPlayerMarkers {
+		if strings.Contains(clean, m) {
+			return fmt.Errorf("%w: %s", ErrNoSuchPlayer, firstLine(clean))
+		}
+	}
+
+	if m := reSyntaxPosition.FindStringSubmatch(firstLine(clean)); m != nil {
+		pos, _ := strconv.Atoi(m[2])
+		return &SyntaxError{Message: m[1], Context: m[3], Position: pos}
+	}
+
+	if strings.Contains(clean, "<--[HERE]") {
+		msg, ctx := splitHere(clean)
+		return &SyntaxError{Message: msg, Context: ctx, Position: -1}
+	}
+
+	return nil
+}
+
+// responseError returns the classified error for resp, or a generic parser
+// error when the response is not a known error message.
+func responseError(resp string, fallback string) error {
+	if err := ClassifyResponse(resp); err != nil {
+		return err
+	}
+	return errors.New(fallback)
+}
+
+// Hint returns a short actionable message for the typed errors.
+func Hint(err error) string {
+	var syntax *SyntaxError
+	switch {
+	case errors.Is(err, ErrUnknownCommand):
+		return "command does not exist on this server or is missing arguments"
+	case errors.Is(err, ErrNoSuchPlayer):
+		return "player is offline or the name is misspelled"
+	case errors.Is(err, ErrPermissionDenied):
+		return "RCON user lacks permission for this command"
+	case errors.As(err, &syntax):
+		if syntax.Position >= 0 {
+			return fmt.Sprintf("check the argument at position %d", syntax.Position)
+		}
+		return "check the argument marked with <--[HERE]"
+	}
+	return ""
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return s
+}
+
+// splitHere splits "message\n...context<--[HERE]" into its two parts. RCON
+// often drops the newline, so the "..." truncation marker is used as well.
+func splitHere(s string) (msg, ctx string) {
+	s = s[:strings.Index(s, "<--[HERE]")]
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return strings.TrimSpace(s[:i]), strings.TrimPrefix(s[i+1:], "...")
+	}
+	if i := strings.LastIndex(s, "..."); i >= 0 {
+		return strings.TrimSpace(s[:i]), s[i+3:]
+	}
+	if i := strings.Index(s, "see below for error"); i >= 0 {
+		return strings.TrimSpace(s[:i+len("see below for error")]), s[i+len("see below for error"):]
+	}
+	return s, ""
+}
+
+func hereContext(s string) string {
+	if !strings.Contains(s, "<--[HERE]") {
+		return firstLine(s)
+	}
+	_, ctx := splitHere(s)
+	return strings.TrimSpace(ctx) + "<--[HERE]"
+}
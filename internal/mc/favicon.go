@@ -0,0 +1,34 @@
+package mc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+)
+
+// DecodeFavicon decodes the "data:image/png;base64,..." favicon from the
+// status response. It returns the image and the raw PNG bytes.
+func DecodeFavicon(favicon string) (image.Image, []byte, error) {
+	const prefix = "data:image/png;base64,"
+	if !strings.HasPrefix(favicon, prefix) {
+		return nil, nil, errors.New("favicon is not a base64 png")
+	}
+
+	// some servers wrap the base64 data like a MIME body
+	data := strings.NewReplacer("\n", "", "\r", "").Replace(favicon[len(prefix):])
+
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	img, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return img, raw, nil
+}
@@ -0,0 +1,50 @@
+package mc
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ChunkPos struct {
+	X, Z int
+}
+
+// BlockX and BlockZ return the block coordinates of the chunk's corner.
+func (c ChunkPos) BlockX() int { return c.X * 16 }
+func (c ChunkPos) BlockZ() int { return c.Z * 16 }
+
+var reChunk = regexp.MustCompile(`\[(-?\d+), (-?\d+)\]`)
+
+// ParseForceloadQuery parses `forceload query`, listing the force loaded
+// chunks of the dimension the command runs in.
+func ParseForceloadQuery(resp string) ([]ChunkPos, error) {
+	if err := ClassifyResponse(resp); err != nil {
+		return nil, err
+	}
+
+	clean := RemoveColorCodes(resp)
+	if strings.Contains(clean, "No force loaded chunks") {
+		return nil, nil
+	}
+
+	i := strings.Index(clean, " at: ")
+	if i < 0 {
+		return nil, responseError(resp, "invalid forceload output")
+	}
+
+	var chunks []ChunkPos
+	for _, m := range reChunk.FindAllStringSubmatch(clean[i:], -1) {
+		x, _ := strconv.Atoi(m[1])
+		z, _ := strconv.Atoi(m[2])
+		chunks = append(chunks, ChunkPos{X: x, Z: z})
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		if chunks[i].Z != chunks[j].Z {
+			return chunks[i].Z < chunks[j].Z
+		}
+		return chunks[i].X < chunks[j].X
+	})
+	return chunks, nil
+}
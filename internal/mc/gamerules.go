@@ -0,0 +1,157 @@
+package mc
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type GameRule struct {
+	Name string
+	// "" when the rule has no known default
+	Default string
+	Bool    bool
+}
+
+// gameRuleDefaults holds the defaults of the vanilla game rules. The rules
+// themselves are listed by the server, this only fills in the defaults.
+var gameRuleDefaults = map[string]string{
+	"allowEnteringNetherUsingPortals":  "true",
+	"allowFireTicksAwayFromPlayer":     "false",
+	"announceAdvancements":             "true",
+	"blockExplosionDropDecay":          "true",
+	"commandBlockOutput":               "true",
+	"commandBlocksEnabled":             "true",
+	"commandModificationBlockLimit":    "32768",
+	"disableElytraMovementCheck":       "false",
+	"disablePlayerMovementCheck":       "false",
+	"disableRaids":                     "false",
+	"doDaylightCycle":                  "true",
+	"doEntityDrops":                    "true",
+	"doFireTick":                       "true",
+	"doImmediateRespawn":               "false",
+	"doInsomnia":                       "true",
+	"doLimitedCrafting":                "false",
+	"doMobLoot":                        "true",
+	"doMobSpawning":                    "true",
+	"doPatrolSpawning":                 "true",
+	"doTileDrops":                      "true",
+	"doTraderSpawning":                 "true",
+	"doVinesSpread":                    "true",
+	"doWardenSpawning":                 "true",
+	"doWeatherCycle":                   "true",
+	"drowningDamage":                   "true",
+	"enderPearlsVanishOnDeath":         "true",
+	"fallDamage":                       "true",
+	"fireDamage":                       "true",
+	"forgiveDeadPlayers":               "true",
+	"freezeDamage":                     "true",
+	"globalSoundEvents":                "true",
+	"keepInventory":                    "false",
+	"lavaSourceConversion":             "false",
+	"locatorBar":                       "true",
+	"logAdminCommands":                 "true",
+	"maxCommandChainLength":            "65536",
+	"maxCommandForkCount":              "65536",
+	"maxEntityCramming":                "24",
+	"mobExplosionDropDecay":            "true",
+	"mobGriefing":                      "true",
+	"naturalRegeneration":              "true",
+	"playersNetherPortalCreativeDelay": "1",
+	"playersNetherPortalDefaultDelay":  "80",
+	"playersSleepingPercentage":        "100",
+	"projectilesCanBreakBlocks":        "true",
+	"pvp":                              "true",
+	"randomTickSpeed":                  "3",
+	"reducedDebugInfo":                 "false",
+	"sendCommandFeedback":              "true",
+	"showDeathMessages":                "true",
+	"snowAccumulationHeight":           "1",
+	"spawnChunkRadius":                 "2",
+	"spawnerBlocksEnabled":             "true",
+	"spawnMonsters":                    "true",
+	"spawnRadius":                      "10",
+	"spectatorsGenerateChunks":         "true",
+	"tntExplodes":                      "true",
+	"tntExplosionDropDecay":            "false",
+	"universalAnger":                   "false",
+	"waterSourceConversion":            "true",
+}
+
+// GameRuleNames returns the rules the server lists as children of the
+// gamerule command, sorted.
+func GameRuleNames(t *CommandTree) []string {
+	c := t.Command("gamerule")
+	if c == nil {
+		return nil
+	}
+	var names []string
+	for _, n := range c.Children {
+		if n.Literal {
+			names = append(names, n.Name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	return names
+}
+
+// NewGameRule returns the rule with its default. The defaults are keyed by
+// the camelCase names, snake_case names like "minecraft:keep_inventory"
+// match them too. Bool is taken from value when the default is not known.
+func NewGameRule(name string, value string) GameRule {
+	r := GameRule{Name: name, Default: gameRuleDefaults[name]}
+	if r.Default == "" {
+		key := normalizeGameRule(name)
+		for n, d := range gameRuleDefaults {
+			if normalizeGameRule(n) == key {
+				r.Default = d
+			}
+		}
+	}
+	v := r.Default
+	if v == "" {
+		v = value
+	}
+	r.Bool = v == "true" || v == "false"
+	return r
+}
+
+func normalizeGameRule(name string) string {
+	name = strings.TrimPrefix(name, "minecraft:")
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+var reGameRule = regexp.MustCompile(`Gamerule (\S+) is (?:currently|now) set to: (\S+)`)
+
+// ParseGameRule parses the response of `gamerule <name> [value]`.
+func ParseGameRule(resp string) (name string, value string, err error) {
+	m := reGameRule.FindStringSubmatch(RemoveColorCodes(resp))
+	if m == nil {
+		return "", "", responseError(resp, "invalid gamerule output")
+	}
+	return m[1], m[2], nil
+}
+
+// SaveGameRuleProfile writes game rule values as a JSON object.
+func SaveGameRuleProfile(path string, rules map[string]string) error {
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func LoadGameRuleProfile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules map[string]string
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,34 @@
+package mc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGameRuleNames(t *testing.T) {
+	tree := NewCommandTree()
+	tree.AddUsage("/gamerule pvp [<value>]/gamerule keepInventory [<value>]/gamerule locatorBar [<value>]/time (add|query|set)")
+
+	want := []string{"keepInventory", "locatorBar", "pvp"}
+	if got := GameRuleNames(tree); !reflect.DeepEqual(got, want) {
+		t.Errorf("GameRuleNames = %q, want %q", got, want)
+	}
+}
+
+func TestNewGameRule(t *testing.T) {
+	tests := []struct {
+		name, value string
+		want        GameRule
+	}{
+		{"keepInventory", "true", GameRule{Name: "keepInventory", Default: "false", Bool: true}},
+		{"minecraft:keep_inventory", "true", GameRule{Name: "minecraft:keep_inventory", Default: "false", Bool: true}},
+		{"randomTickSpeed", "3", GameRule{Name: "randomTickSpeed", Default: "3"}},
+		{"someModRule", "false", GameRule{Name: "someModRule", Bool: true}},
+		{"someModLimit", "12", GameRule{Name: "someModLimit"}},
+	}
+	for _, tt := range tests {
+		if got := NewGameRule(tt.name, tt.value); got != tt.want {
+			t.Errorf("NewGameRule(%q, %q) = %+v, want %+v", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package mc
+
+// BlockIDs are common vanilla blocks, suggested when the server's
+// registries report is not available.
+var BlockIDs = []string{
+	// terrain
+	"air", "stone", "granite", "diorite", "andesite", "deepslate",
+	"cobblestone", "cobbled_deepslate", "dirt", "grass_block", "podzol",
+	"mycelium", "sand", "red_sand", "gravel", "clay", "snow_block", "ice",
+	"packed_ice", "blue_ice", "bedrock", "obsidian", "crying_obsidian",
+	"netherrack", "soul_sand", "soul_soil", "basalt", "blackstone",
+	"end_stone", "water", "lava",
+
+	// ores
+	"coal_ore", "iron_ore", "copper_ore", "gold_ore", "redstone_ore",
+	"lapis_ore", "diamond_ore", "emerald_ore", "ancient_debris",
+	"coal_block", "iron_block", "copper_block", "gold_block",
+	"redstone_block", "lapis_block", "diamond_block", "emerald_block",
+	"netherite_block",
+
+	// building
+	"oak_log", "spruce_log", "birch_log", "jungle_log", "acacia_log",
+	"dark_oak_log", "mangrove_log", "cherry_log", "oak_planks",
+	"spruce_planks", "birch_planks", "jungle_planks", "acacia_planks",
+	"dark_oak_planks", "mangrove_planks", "cherry_planks", "bamboo_planks",
+	"stone_bricks", "bricks", "glass", "white_wool", "white_concrete",
+	"terracotta", "quartz_block", "sandstone", "prismarine", "purpur_block",
+	"torch", "lantern", "glowstone", "sea_lantern",
+
+	// utility
+	"chest", "barrel", "crafting_table", "furnace", "blast_furnace",
+	"smoker", "anvil", "enchanting_table", "bookshelf", "hopper",
+	"dispenser", "dropper", "observer", "piston", "sticky_piston",
+	"redstone_wire", "redstone_torch", "repeater", "comparator", "lever",
+	"stone_button", "tnt", "spawner", "beacon", "conduit", "respawn_anchor",
+	"command_block", "chain_command_block", "repeating_command_block",
+	"structure_block", "barrier", "light",
+}
+
+// ItemIDs are common vanilla items that are not blocks, see BlockIDs.
+var ItemIDs = []string{
+	// tools and weapons
+	"wooden_sword", "stone_sword", "iron_sword", "golden_sword",
+	"diamond_sword", "netherite_sword", "wooden_pickaxe", "stone_pickaxe",
+	"iron_pickaxe", "golden_pickaxe", "diamond_pickaxe", "netherite_pickaxe",
+	"iron_axe", "diamond_axe", "netherite_axe", "iron_shovel",
+	"diamond_shovel", "netherite_shovel", "diamond_hoe", "bow", "crossbow",
+	"trident", "mace", "arrow", "spectral_arrow", "shield", "fishing_rod",
+	"flint_and_steel", "shears", "compass", "clock", "spyglass", "lead",
+
+	// armor
+	"leather_helmet", "iron_helmet", "diamond_helmet", "netherite_helmet",
+	"iron_chestplate", "diamond_chestplate", "netherite_chestplate",
+	"iron_leggings", "diamond_leggings", "netherite_leggings",
+	"iron_boots", "diamond_boots", "netherite_boots", "elytra",
+	"turtle_helmet",
+
+	// materials
+	"coal", "charcoal", "raw_iron", "raw_copper", "raw_gold",
+	"iron_ingot", "copper_ingot", "gold_ingot", "netherite_ingot",
+	"netherite_scrap", "diamond", "emerald", "lapis_lazuli", "redstone",
+	"quartz", "amethyst_shard", "stick", "string", "feather", "leather",
+	"paper", "book", "gunpowder", "blaze_rod", "ender_pearl", "ender_eye",
+	"slime_ball", "bone", "experience_bottle", "nether_star",
+
+	// food
+	"apple", "golden_apple", "enchanted_golden_apple", "bread",
+	"cooked_beef", "cooked_porkchop", "cooked_chicken", "cooked_mutton",
+	"baked_potato", "carrot", "golden_carrot", "cookie", "cake",
+
+	// misc
+	"water_bucket", "lava_bucket", "bucket", "milk_bucket", "totem_of_undying",
+	"firework_rocket", "name_tag", "saddle", "written_book",
+	"writable_book", "enchanted_book", "filled_map", "map",
+	"oak_boat", "minecart", "white_bed", "shulker_box", "ender_chest",
+}
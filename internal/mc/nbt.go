@@ -0,0 +1,27 @@
+package mc
+
+import (
+	"compress/gzip"
+	"os"
+
+	"github.com/Tnze/go-mc/nbt"
+)
+
+// readGzipNBT decodes a gzip compressed NBT file such as level.dat,
+// scoreboard.dat or playerdata/<uuid>.dat into v.
+func readGzipNBT(path string, v any) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	_, err = nbt.NewDecoder(zr).Decode(v)
+	return err
+}
@@ -0,0 +1,47 @@
+package mc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Operator struct {
+	UUID                string `json:"uuid"`
+	Name                string `json:"name"`
+	Level               int    `json:"level"`
+	BypassesPlayerLimit bool   `json:"bypassesPlayerLimit"`
+}
+
+// ReadOps reads ops.json from the server directory.
+func ReadOps(serverDir string) ([]Operator, error) {
+	data, err := os.ReadFile(filepath.Join(serverDir, "ops.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []Operator
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// ParseOpResponse parses the response of `op` and `deop`. Changed is false
+// for the "Nothing changed" responses, isOp is the resulting state.
+func ParseOpResponse(resp string) (changed bool, isOp bool, err error) {
+	clean := RemoveColorCodes(resp)
+
+	switch {
+	case strings.Contains(clean, "no longer a server operator"):
+		return true, false, nil
+	case strings.Contains(clean, "a server operator"):
+		return true, true, nil
+	case strings.Contains(clean, "already is an operator"):
+		return false, true, nil
+	case strings.Contains(clean, "is not an operator"):
+		return false, false, nil
+	}
+	return false, false, responseError(resp, "invalid op output")
+}
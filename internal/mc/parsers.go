@@ -1,7 +1,6 @@
 package mc
 
 import (
-	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -19,6 +18,9 @@ func parseTrailingInt(input string) (int, error) {
 	re := regexp.MustCompile(`(\d+)$`)
 	m := re.FindStringSubmatch(input)
 	if len(m) != 2 {
+		if err := ClassifyResponse(input); err != nil {
+			return 0, err
+		}
 		return 0, fmt.Errorf("no trailing int in %q", input)
 	}
 	return strconv.Atoi(m[1])
@@ -55,14 +57,14 @@ func ParseTime(input string) string {
 	return fmt.Sprintf("%02d:%02d %s", hour12, minutes, period)
 }
 
-func ParseTPS(input string) (t1, t5, t15 float64) {
+func ParseTPS(input string) (t1, t5, t15 float64, err error) {
 	// 1. Usuwamy kolory (§a, §f itp.)
 	clean := regexp.MustCompile(`§.`).ReplaceAllString(input, "")
 
 	// 2. Szukamy wszystkiego, co jest po dwukropku
 	parts := strings.Split(clean, ":")
 	if len(parts) < 2 {
-		return 0, 0, 0
+		return 0, 0, 0, responseError(input, "invalid tps output")
 	}
 
 	// 3. Wyciągamy liczby tylko z części po dwukropku
@@ -70,7 +72,7 @@ func ParseTPS(input string) (t1, t5, t15 float64) {
 	matches := re.FindAllString(parts[1], -1)
 
 	if len(matches) < 3 {
-		return 0, 0, 0
+		return 0, 0, 0, responseError(input, "invalid tps output")
 	}
 
 	// Helper do konwersji
@@ -79,7 +81,7 @@ func ParseTPS(input string) (t1, t5, t15 float64) {
 		return val
 	}
 
-	return pf(matches[0]), pf(matches[1]), pf(matches[2])
+	return pf(matches[0]), pf(matches[1]), pf(matches[2]), nil
 }
 
 type Vec3 struct {
@@ -90,7 +92,7 @@ func ParsePosition(input string) (Vec3, error) {
 	re := regexp.MustCompile(`\[(.+?)d, (.+?)d, (.+?)d\]`)
 	m := re.FindStringSubmatch(input)
 	if len(m) != 4 {
-		return Vec3{}, errors.New("invalid position output")
+		return Vec3{}, responseError(input, "invalid position output")
 	}
 
 	x, _ := strconv.ParseFloat(m[1], 64)
@@ -104,7 +106,7 @@ func ParseHealth(input string) (float64, error) {
 	re := regexp.MustCompile(`: ([0-9.]+)f`)
 	m := re.FindStringSubmatch(input)
 	if len(m) != 2 {
-		return 0, errors.New("invalid health output")
+		return 0, responseError(input, "invalid health output")
 	}
 	return strconv.ParseFloat(m[1], 64)
 }
@@ -125,7 +127,7 @@ func ParseDimension(input string) (string, error) {
 	re := regexp.MustCompile(`"minecraft:(.+)"`)
 	m := re.FindStringSubmatch(input)
 	if len(m) != 2 {
-		return "", errors.New("invalid dimension output")
+		return "", responseError(input, "invalid dimension output")
 	}
 	return m[1], nil
 }
@@ -151,7 +153,7 @@ func ParseSelectedItem(input string) (SelectedItem, error) {
 	countMatch := reCount.FindStringSubmatch(input)
 
 	if idMatch == nil || countMatch == nil {
-		return SelectedItem{}, errors.New("invalid selected item output")
+		return SelectedItem{}, responseError(input, "invalid selected item output")
 	}
 
 	strconvCount, err := strconv.Atoi(countMatch[1])
@@ -168,11 +170,20 @@ func ParseSelectedItem(input string) (SelectedItem, error) {
 }
 
 func ParseScoreboardInt(input string) (int, error) {
-	input = strings.TrimSpace(input)
-	re := regexp.MustCompile(`(\d+)$`)
+	input = strings.TrimSpace(RemoveColorCodes(input))
+
+	// "Steve has 5 [Kills]" / "Can't get value of Kills for Steve; none is set"
+	if strings.Contains(input, "none is set") {
+		return 0, ErrNoScore
+	}
+	if m := regexp.MustCompile(`has (-?\d+) \[`).FindStringSubmatch(input); len(m) == 2 {
+		return strconv.Atoi(m[1])
+	}
+
+	re := regexp.MustCompile(`(-?\d+)$`)
 	m := re.FindStringSubmatch(input)
 	if len(m) != 2 {
-		return 0, errors.New("invalid scoreboard output")
+		return 0, responseError(input, "invalid scoreboard output")
 	}
 	return strconv.Atoi(m[1])
 }
@@ -20,6 +20,10 @@ type StatusResponse struct {
 		Online int `json:"online"`
 	} `json:"players"`
 	Description json.RawMessage `json:"description"`
+	ForgeData   json.RawMessage `json:"forgeData,omitempty"`
+	ModInfo     json.RawMessage `json:"modinfo,omitempty"`
+	IsModded    bool            `json:"isModded,omitempty"`
+	Favicon     string          `json:"favicon,omitempty"`
 }
 
 func Ping(host string, port string) (StatusResponse, time.Duration, error) {
@@ -0,0 +1,128 @@
+package mc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Tnze/go-mc/nbt"
+)
+
+type CachedUser struct {
+	Name      string `json:"name"`
+	UUID      string `json:"uuid"`
+	ExpiresOn string `json:"expiresOn"`
+}
+
+// ReadUserCache reads usercache.json from the server directory. It maps the
+// names of every player that joined recently to their UUID.
+func ReadUserCache(serverDir string) ([]CachedUser, error) {
+	data, err := os.ReadFile(filepath.Join(serverDir, "usercache.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var users []CachedUser
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Name) < strings.ToLower(users[j].Name)
+	})
+	return users, nil
+}
+
+type InventoryItem struct {
+	Slot  int
+	ID    string
+	Count int
+}
+
+// OfflinePlayer is the state of a player saved in playerdata/<uuid>.dat.
+type OfflinePlayer struct {
+	UUID       string
+	Pos        Vec3
+	Health     float64
+	Food       int
+	XPLevel    int
+	XPProgress float64
+	Dimension  string
+	HeldItem   SelectedItem
+	Inventory  []InventoryItem
+	SavedAt    time.Time
+}
+
+type playerDataFile struct {
+	Dimension        nbt.RawMessage
+	Pos              []float64
+	Health           float32
+	FoodLevel        int32 `nbt:"foodLevel"`
+	XpLevel          int32
+	XpP              float32
+	SelectedItemSlot int32
+	Inventory        []struct {
+		Slot int8
+		ID   string `nbt:"id"`
+		// 1.20.5 renamed Count (byte) to count (int)
+		Count    int32 `nbt:"count"`
+		OldCount int8  `nbt:"Count"`
+	}
+}
+
+// legacyDimensions maps the numeric dimension ids used before 1.16.
+var legacyDimensions = map[int32]string{-1: "the_nether", 0: "overworld", 1: "the_end"}
+
+// ReadPlayerData reads the saved state of a player from the world
+// directory. The file is written when the player leaves or the world is saved.
+func ReadPlayerData(worldDir string, uuid string) (OfflinePlayer, error) {
+	path := filepath.Join(worldDir, "playerdata", uuid+".dat")
+	info, err := os.Stat(path)
+	if err != nil {
+		return OfflinePlayer{}, err
+	}
+
+	var f playerDataFile
+	if err := readGzipNBT(path, &f); err != nil {
+		return OfflinePlayer{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	p := OfflinePlayer{
+		UUID:       uuid,
+		Health:     float64(f.Health),
+		Food:       int(f.FoodLevel),
+		XPLevel:    int(f.XpLevel),
+		XPProgress: float64(f.XpP),
+		SavedAt:    info.ModTime(),
+		HeldItem:   SelectedItem{Empty: true},
+	}
+	if len(f.Pos) == 3 {
+		p.Pos = Vec3{X: f.Pos[0], Y: f.Pos[1], Z: f.Pos[2]}
+	}
+
+	var dim string
+	var legacy int32
+	if f.Dimension.Unmarshal(&dim) == nil {
+		p.Dimension = strings.TrimPrefix(dim, "minecraft:")
+	} else if f.Dimension.Unmarshal(&legacy) == nil {
+		p.Dimension = legacyDimensions[legacy]
+	}
+
+	for _, it := range f.Inventory {
+		count := int(it.Count)
+		if count == 0 {
+			count = int(it.OldCount)
+		}
+		item := InventoryItem{Slot: int(it.Slot), ID: it.ID, Count: count}
+		p.Inventory = append(p.Inventory, item)
+
+		if item.Slot == int(f.SelectedItemSlot) {
+			p.HeldItem = SelectedItem{ID: item.ID, Count: item.Count}
+		}
+	}
+
+	return p, nil
+}
@@ -1,6 +1,9 @@
 package mc
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
 func ParsePlayers(resp string) []string {
 	// resp = "There are 2 of a max 20 players online: Player1, Player2, Player3, Player4, Player2, Player3, Player4, Player2, Player3, Player4"
@@ -32,32 +35,72 @@ func ParsePlayers(resp string) []string {
 	return players
 }
 
-func DiffAdded(old, new []string) []string {
+type Player struct {
+	Name string
+	UUID string // empty when the server did not report it
+}
+
+// ID returns the key identifying the player across name changes.
+func (p Player) ID() string {
+	if p.UUID != "" {
+		return p.UUID
+	}
+	return p.Name
+}
+
+var rePlayerUUID = regexp.MustCompile(`^(.+?) \(([0-9a-fA-F-]{32,36})\)$`)
+
+// ParsePlayersWithUUIDs parses `list uuids`, where every entry is written as
+// "Name (uuid)". Entries without a UUID keep only the name.
+func ParsePlayersWithUUIDs(resp string) []Player {
+	var players []Player
+	for _, entry := range ParsePlayers(resp) {
+		if m := rePlayerUUID.FindStringSubmatch(entry); m != nil {
+			players = append(players, Player{Name: m[1], UUID: strings.ToLower(m[2])})
+			continue
+		}
+		players = append(players, Player{Name: entry})
+	}
+	return players
+}
+
+// DiffAdded returns the players in new that are not in old. Players are
+// matched by UUID, so a renamed player is not reported.
+func DiffAdded(old, new []Player) []Player {
 	set := make(map[string]struct{})
 	for _, o := range old {
-		set[o] = struct{}{}
+		set[o.ID()] = struct{}{}
 	}
 
-	var out []string
+	var out []Player
 	for _, n := range new {
-		if _, ok := set[n]; !ok {
+		if _, ok := set[n.ID()]; !ok {
 			out = append(out, n)
 		}
 	}
 	return out
 }
 
-func DiffRemoved(old, new []string) []string {
-	set := make(map[string]struct{})
-	for _, n := range new {
-		set[n] = struct{}{}
-	}
+// DiffRemoved returns the players in old that are not in new.
+func DiffRemoved(old, new []Player) []Player {
+	return DiffAdded(new, old)
+}
 
-	var out []string
+// DiffRenamed returns the players in new whose name differs from the player
+// with the same UUID in old.
+func DiffRenamed(old, new []Player) (renamed []Player, oldNames []string) {
+	names := make(map[string]string)
 	for _, o := range old {
-		if _, ok := set[o]; !ok {
-			out = append(out, o)
+		if o.UUID != "" {
+			names[o.UUID] = o.Name
 		}
 	}
-	return out
+
+	for _, n := range new {
+		if prev, ok := names[n.UUID]; ok && prev != n.Name {
+			renamed = append(renamed, n)
+			oldNames = append(oldNames, prev)
+		}
+	}
+	return renamed, oldNames
 }
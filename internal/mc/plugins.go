@@ -0,0 +1,240 @@
+package mc
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Plugin is a Bukkit/Paper plugin or a Forge mod.
+type Plugin struct {
+	Name    string
+	Version string
+	Kind    string // "Bukkit", "Paper", "Forge", ...
+	Enabled bool
+}
+
+var (
+	rePluginsHeader  = regexp.MustCompile(`Plugins \(\d+\):`)
+	rePluginsSection = regexp.MustCompile(`(\w+) Plugins:`)
+)
+
+// ParsePlugins parses the Bukkit `plugins` command. Both the classic one
+// line format and the sectioned Paper 1.20+ format are supported.
+func ParsePlugins(resp string) ([]Plugin, error) {
+	loc := rePluginsHeader.FindStringIndex(resp)
+	if loc == nil {
+		return nil, responseError(resp, "invalid plugins output")
+	}
+	body := resp[loc[1]:]
+
+	type section struct {
+		kind string
+		text string
+	}
+	var sections []section
+
+	idx := rePluginsSection.FindAllStringSubmatchIndex(body, -1)
+	if len(idx) == 0 {
+		sections = append(sections, section{kind: "Bukkit", text: body})
+	}
+	for i, m := range idx {
+		end := len(body)
+		if i+1 < len(idx) {
+			end = idx[i+1][0]
+		}
+		sections = append(sections, section{kind: body[m[2]:m[3]], text: body[m[1]:end]})
+	}
+
+	var plugins []Plugin
+	for _, s := range sections {
+		text := strings.NewReplacer("\n", ",", " - ", ",").Replace(s.text)
+		for _, raw := range strings.Split(text, ",") {
+			raw = strings.TrimSpace(raw)
+			name := strings.TrimSpace(strings.Trim(RemoveColorCodes(raw), "-*"))
+			if name == "" {
+				continue
+			}
+			plugins = append(plugins, Plugin{
+				Name: name,
+				Kind: s.kind,
+				// disabled plugins are printed in red
+				Enabled: !strings.HasPrefix(raw, "§c"),
+			})
+		}
+	}
+
+	return plugins, nil
+}
+
+var rePluginVersion = regexp.MustCompile(`(?m)^\s*(\S+) version (\S+)`)
+
+// ParsePluginVersion parses `version <plugin>`.
+func ParsePluginVersion(resp string) (string, error) {
+	m := rePluginVersion.FindStringSubmatch(RemoveColorCodes(resp))
+	if m == nil {
+		return "", responseError(resp, "invalid plugin version output")
+	}
+	return m[2], nil
+}
+
+type forgeData struct {
+	Mods []struct {
+		ModID  string `json:"modId"`
+		Marker string `json:"modmarker"`
+	} `json:"mods"`
+	D string `json:"d"`
+}
+
+type legacyModInfo struct {
+	ModList []struct {
+		ModID   string `json:"modid"`
+		Version string `json:"version"`
+	} `json:"modList"`
+}
+
+// ParseForgeMods returns the mod list advertised in the status response.
+func ParseForgeMods(status StatusResponse) ([]Plugin, error) {
+	var mods []Plugin
+
+	switch {
+	case status.ForgeData != nil:
+		var fd forgeData
+		if err := json.Unmarshal(status.ForgeData, &fd); err != nil {
+			return nil, err
+		}
+		for _, m := range fd.Mods {
+			mods = append(mods, Plugin{Name: m.ModID, Version: m.Marker, Kind: "Forge", Enabled: true})
+		}
+		if fd.D != "" {
+			decoded, err := decodeForgeOptimized(fd.D)
+			if err != nil {
+				return nil, err
+			}
+			mods = append(mods, decoded...)
+		}
+
+	case status.ModInfo != nil:
+		var mi legacyModInfo
+		if err := json.Unmarshal(status.ModInfo, &mi); err != nil {
+			return nil, err
+		}
+		for _, m := range mi.ModList {
+			mods = append(mods, Plugin{Name: m.ModID, Version: m.Version, Kind: "FML", Enabled: true})
+		}
+
+	default:
+		return nil, errors.New("server does not advertise a mod list")
+	}
+
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Name < mods[j].Name })
+	return mods, nil
+}
+
+// decodeForgeOptimized decodes the "d" field Forge 1.18.2+ uses to pack the
+// mod list: 15 bits of a binary buffer are stored in every UTF-16 char.
+func decodeForgeOptimized(s string) ([]Plugin, error) {
+	chars := []rune(s)
+	if len(chars) < 2 {
+		return nil, errors.New("invalid forge mod data")
+	}
+	size := int(chars[0]) | int(chars[1])<<15
+
+	buf := make([]byte, 0, size)
+	var acc, bits uint32
+	for _, c := range chars[2:] {
+		for bits >= 8 {
+			buf = append(buf, byte(acc))
+			acc >>= 8
+			bits -= 8
+		}
+		acc |= (uint32(c) & 0x7FFF) << bits
+		bits += 15
+	}
+	for len(buf) < size {
+		buf = append(buf, byte(acc))
+		acc >>= 8
+	}
+	buf = buf[:size]
+
+	r := &byteReader{buf: buf}
+	r.byte() // truncated flag
+	count := int(r.uint16())
+
+	var mods []Plugin
+	for i := 0; i < count && r.err == nil; i++ {
+		flag := r.varint()
+		channels := flag >> 1
+		name := r.string()
+		version := ""
+		if flag&1 == 0 {
+			version = r.string()
+		}
+		for c := 0; c < channels; c++ {
+			r.string()
+			r.string()
+			r.byte()
+		}
+		mods = append(mods, Plugin{Name: name, Version: version, Kind: "Forge", Enabled: true})
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return mods, nil
+}
+
+type byteReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+var errShortBuffer = errors.New("forge mod data is truncated")
+
+func (r *byteReader) byte() byte {
+	if r.err != nil || r.pos >= len(r.buf) {
+		r.err = errShortBuffer
+		return 0
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *byteReader) uint16() uint16 {
+	if r.err != nil || r.pos+2 > len(r.buf) {
+		r.err = errShortBuffer
+		return 0
+	}
+	v := binary.BigEndian.Uint16(r.buf[r.pos:])
+	r.pos += 2
+	return v
+}
+
+func (r *byteReader) varint() int {
+	var v, shift int
+	for i := 0; i < 5; i++ {
+		b := r.byte()
+		v |= int(b&0x7F) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+	}
+	return v
+}
+
+func (r *byteReader) string() string {
+	n := r.varint()
+	if r.err != nil || n < 0 || r.pos+n > len(r.buf) {
+		r.err = errShortBuffer
+		return ""
+	}
+	s := string(r.buf[r.pos : r.pos+n])
+	r.pos += n
+	return s
+}
@@ -0,0 +1,155 @@
+package mc
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Tnze/go-mc/nbt"
+)
+
+var ErrNoScore = errors.New("no score set")
+
+type Objective struct {
+	Name        string
+	DisplayName string
+	Criteria    string
+}
+
+var reBracketed = regexp.MustCompile(`\[([^\]]+)\]`)
+
+// ParseObjectivesList parses `scoreboard objectives list`. The server only
+// prints display names, which equal the objective names unless a custom
+// display name was set.
+func ParseObjectivesList(resp string) ([]string, error) {
+	clean := RemoveColorCodes(resp)
+
+	if strings.Contains(clean, "There are no objectives") {
+		return nil, nil
+	}
+
+	i := strings.Index(clean, "objective(s):")
+	if i < 0 {
+		return nil, responseError(resp, "invalid objectives output")
+	}
+
+	var names []string
+	for _, m := range reBracketed.FindAllStringSubmatch(clean[i:], -1) {
+		names = append(names, m[1])
+	}
+	return names, nil
+}
+
+// ParseTrackedEntities parses `scoreboard players list` without arguments.
+func ParseTrackedEntities(resp string) ([]string, error) {
+	clean := RemoveColorCodes(resp)
+
+	if strings.Contains(clean, "There are no tracked entities") {
+		return nil, nil
+	}
+
+	i := strings.Index(clean, "entity/entities:")
+	if i < 0 {
+		return nil, responseError(resp, "invalid tracked entities output")
+	}
+
+	var names []string
+	for _, n := range strings.Split(clean[i+len("entity/entities:"):], ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names, nil
+}
+
+var reEntityScore = regexp.MustCompile(`\[([^\]]+)\]: (-?\d+)`)
+
+// ParseEntityScores parses `scoreboard players list <entity>` into scores
+// keyed by objective display name.
+func ParseEntityScores(resp string) (map[string]int, error) {
+	clean := RemoveColorCodes(resp)
+	scores := map[string]int{}
+
+	if strings.Contains(clean, "has no scores") {
+		return scores, nil
+	}
+	if !strings.Contains(clean, "score(s):") {
+		return nil, responseError(resp, "invalid entity scores output")
+	}
+
+	for _, m := range reEntityScore.FindAllStringSubmatch(clean, -1) {
+		v, _ := strconv.Atoi(m[2])
+		scores[m[1]] = v
+	}
+	return scores, nil
+}
+
+type scoreboardFile struct {
+	Data struct {
+		Objectives []struct {
+			Name         string
+			CriteriaName string
+			DisplayName  nbt.RawMessage
+		}
+		DisplaySlots map[string]string
+	} `nbt:"data"`
+}
+
+// ReadScoreboard reads objectives and display slots from data/scoreboard.dat.
+// The file is only written when the world is saved.
+func ReadScoreboard(worldDir string) ([]Objective, map[string]string, error) {
+	var f scoreboardFile
+	if err := readGzipNBT(filepath.Join(worldDir, "data", "scoreboard.dat"), &f); err != nil {
+		return nil, nil, err
+	}
+
+	var objectives []Objective
+	for _, o := range f.Data.Objectives {
+		objectives = append(objectives, Objective{
+			Name:        o.Name,
+			DisplayName: componentText(o.DisplayName),
+			Criteria:    o.CriteriaName,
+		})
+	}
+
+	// 1.20.2 renamed the numbered slots
+	slots := map[string]string{}
+	legacy := map[string]string{"slot_0": "list", "slot_1": "sidebar", "slot_2": "below_name"}
+	for k, v := range f.Data.DisplaySlots {
+		if name, ok := legacy[k]; ok {
+			k = name
+		}
+		slots[k] = v
+	}
+
+	return objectives, slots, nil
+}
+
+// componentText returns the plain text of a text component stored either as
+// a JSON string (before 1.20.5) or as an NBT compound.
+func componentText(raw nbt.RawMessage) string {
+	var s string
+	if err := raw.Unmarshal(&s); err == nil {
+		var c struct {
+			Text string `json:"text"`
+		}
+		if json.Unmarshal([]byte(s), &c) == nil {
+			return c.Text
+		}
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+		return s
+	}
+
+	var c struct {
+		Text string `nbt:"text"`
+	}
+	if err := raw.Unmarshal(&c); err == nil {
+		return c.Text
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package mc
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Software string
+
+const (
+	SoftwareUnknown  Software = ""
+	SoftwareVanilla  Software = "Vanilla"
+	SoftwarePaper    Software = "Paper"
+	SoftwarePurpur   Software = "Purpur"
+	SoftwareSpigot   Software = "Spigot"
+	SoftwareFabric   Software = "Fabric"
+	SoftwareForge    Software = "Forge"
+	SoftwareNeoForge Software = "NeoForge"
+)
+
+// IsBukkit reports whether the server runs the Bukkit plugin API.
+func (s Software) IsBukkit() bool {
+	return s == SoftwarePaper || s == SoftwarePurpur || s == SoftwareSpigot
+}
+
+// IsForge reports whether the server is Forge or one of its forks.
+func (s Software) IsForge() bool {
+	return s == SoftwareForge || s == SoftwareNeoForge
+}
+
+var reRunning = regexp.MustCompile(`running\s+(\w+)\s+version`)
+
+// DetectSoftware guesses the server software from the `version` command
+// response and the status ping.
+func DetectSoftware(versionResp string, status StatusResponse) Software {
+	clean := RemoveColorCodes(versionResp)
+
+	if m := reRunning.FindStringSubmatch(clean); m != nil {
+		switch strings.ToLower(m[1]) {
+		case "paper", "folia":
+			return SoftwarePaper
+		case "purpur", "pufferfish":
+			return SoftwarePurpur
+		default:
+			return SoftwareSpigot
+		}
+	}
+
+	name := strings.ToLower(status.Version.Name)
+
+	if status.ForgeData != nil || status.ModInfo != nil || status.IsModded {
+		if strings.Contains(name, "neoforge") || (status.IsModded && status.ForgeData == nil) {
+			return SoftwareNeoForge
+		}
+		return SoftwareForge
+	}
+
+	// Fabric does not announce itself, only proxies and some mods put the
+	// loader name into the version string
+	if strings.Contains(name, "fabric") || strings.Contains(name, "quilt") {
+		return SoftwareFabric
+	}
+
+	return SoftwareVanilla
+}
@@ -0,0 +1,113 @@
+package mc
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/Tnze/go-mc/nbt"
+)
+
+type Team struct {
+	Name        string
+	DisplayName string
+	Members     []string
+
+	// only known from scoreboard.dat or after changing them
+	Options map[string]string
+}
+
+// ParseTeamList parses `team list`. Like objectives, teams are printed by
+// their display names.
+func ParseTeamList(resp string) ([]string, error) {
+	clean := RemoveColorCodes(resp)
+
+	if strings.Contains(clean, "There are no teams") {
+		return nil, nil
+	}
+
+	i := strings.Index(clean, "team(s):")
+	if i < 0 {
+		return nil, responseError(resp, "invalid team list output")
+	}
+
+	var names []string
+	for _, m := range reBracketed.FindAllStringSubmatch(clean[i:], -1) {
+		names = append(names, m[1])
+	}
+	return names, nil
+}
+
+// ParseTeamMembers parses `team list <team>`.
+func ParseTeamMembers(resp string) ([]string, error) {
+	clean := RemoveColorCodes(resp)
+
+	if strings.Contains(clean, "There are no members on team") {
+		return nil, nil
+	}
+
+	i := strings.Index(clean, "member(s):")
+	if i < 0 {
+		return nil, responseError(resp, "invalid team members output")
+	}
+
+	var members []string
+	for _, n := range strings.Split(clean[i+len("member(s):"):], ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			members = append(members, n)
+		}
+	}
+	return members, nil
+}
+
+type teamsFile struct {
+	Data struct {
+		Teams []struct {
+			Name                   string
+			DisplayName            nbt.RawMessage
+			TeamColor              string
+			AllowFriendlyFire      byte
+			SeeFriendlyInvisibles  byte
+			NameTagVisibility      string
+			DeathMessageVisibility string
+			CollisionRule          string
+			Players                []string
+		}
+	} `nbt:"data"`
+}
+
+// ReadTeams reads teams with their options from data/scoreboard.dat.
+func ReadTeams(worldDir string) ([]Team, error) {
+	var f teamsFile
+	if err := readGzipNBT(filepath.Join(worldDir, "data", "scoreboard.dat"), &f); err != nil {
+		return nil, err
+	}
+
+	boolText := func(b byte) string {
+		if b != 0 {
+			return "true"
+		}
+		return "false"
+	}
+
+	var teams []Team
+	for _, t := range f.Data.Teams {
+		team := Team{
+			Name:        t.Name,
+			DisplayName: componentText(t.DisplayName),
+			Members:     t.Players,
+			Options: map[string]string{
+				"color":                  t.TeamColor,
+				"friendlyFire":           boolText(t.AllowFriendlyFire),
+				"seeFriendlyInvisibles":  boolText(t.SeeFriendlyInvisibles),
+				"nametagVisibility":      t.NameTagVisibility,
+				"deathMessageVisibility": t.DeathMessageVisibility,
+				"collisionRule":          t.CollisionRule,
+			},
+		}
+		if team.DisplayName == "" {
+			team.DisplayName = team.Name
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
@@ -0,0 +1,67 @@
+package mc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var reTimeQuery = regexp.MustCompile(`The time is (\d+)`)
+
+// ParseTimeTicks parses the number from `time query daytime|gametime|day`.
+func ParseTimeTicks(input string) (int, error) {
+	m := reTimeQuery.FindStringSubmatch(input)
+	if len(m) != 2 {
+		return 0, responseError(input, "invalid time output")
+	}
+	return strconv.Atoi(m[1])
+}
+
+var moonPhases = []string{
+	"full moon",
+	"waning gibbous",
+	"third quarter",
+	"waning crescent",
+	"new moon",
+	"waxing crescent",
+	"first quarter",
+	"waxing gibbous",
+}
+
+var moonIcons = []string{"●", "◕", "◑", "◔", "○", "◔", "◐", "◕"}
+
+// MoonPhase returns the name and icon of the moon phase for the given day.
+func MoonPhase(day int) (name string, icon string) {
+	i := day % 8
+	if i < 0 {
+		i += 8
+	}
+	return moonPhases[i], moonIcons[i]
+}
+
+type Weather string
+
+const (
+	WeatherUnknown Weather = ""
+	WeatherClear   Weather = "clear"
+	WeatherRain    Weather = "rain"
+	WeatherThunder Weather = "thunder"
+)
+
+// ParseTestResult parses the `execute if ...` result. Count is 0 for tests
+// that do not report one.
+func ParseTestResult(input string) (passed bool, count int, err error) {
+	clean := strings.TrimSpace(RemoveColorCodes(input))
+
+	switch {
+	case strings.HasPrefix(clean, "Test passed"):
+		if m := regexp.MustCompile(`count: (\d+)`).FindStringSubmatch(clean); m != nil {
+			count, _ = strconv.Atoi(m[1])
+		}
+		return true, count, nil
+	case strings.HasPrefix(clean, "Test failed"):
+		return false, 0, nil
+	}
+
+	return false, 0, responseError(input, "invalid test output")
+}
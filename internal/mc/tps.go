@@ -0,0 +1,164 @@
+package mc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type DimensionTPS struct {
+	Name string
+	TPS  float64
+	MSPT float64
+}
+
+// ParseMSPT parses Paper's `mspt` output and returns the avg/min/max tick
+// time over the last 5 seconds.
+func ParseMSPT(input string) (avg, min, max float64, err error) {
+	clean := RemoveColorCodes(input)
+
+	re := regexp.MustCompile(`([\d.]+)/([\d.]+)/([\d.]+)`)
+	m := re.FindStringSubmatch(clean)
+	if len(m) != 4 {
+		return 0, 0, 0, responseError(input, "invalid mspt output")
+	}
+
+	avg, _ = strconv.ParseFloat(m[1], 64)
+	min, _ = strconv.ParseFloat(m[2], 64)
+	max, _ = strconv.ParseFloat(m[3], 64)
+	return avg, min, max, nil
+}
+
+var (
+	// Forge: "Dim minecraft:overworld (minecraft:overworld): Mean tick time: 1.234 ms. Mean TPS: 20.000"
+	reForgeTPS = regexp.MustCompile(`(?:Dim\s+)?([\w:./-]+)(?:\s+\([^)]*\))?\s*:\s*Mean tick time:\s*([\d.]+)\s*ms\.?\s*Mean TPS:\s*([\d.]+)`)
+	// NeoForge: "minecraft:overworld: 20.000 TPS (1.234 ms/tick)"
+	reNeoForgeTPS = regexp.MustCompile(`([\w:./-]+)\s*:\s*([\d.]+)\s*TPS\s*\(([\d.]+)\s*ms/tick\)`)
+)
+
+// ParseForgeTPS parses `forge tps` and `neoforge tps` output into the
+// overall value and per-dimension values.
+func ParseForgeTPS(input string) (overall DimensionTPS, dims []DimensionTPS, err error) {
+	clean := RemoveColorCodes(input)
+
+	pf := func(s string) float64 {
+		val, _ := strconv.ParseFloat(s, 64)
+		return val
+	}
+
+	var entries []DimensionTPS
+	for _, m := range reForgeTPS.FindAllStringSubmatch(clean, -1) {
+		entries = append(entries, DimensionTPS{Name: m[1], MSPT: pf(m[2]), TPS: pf(m[3])})
+	}
+	if len(entries) == 0 {
+		for _, m := range reNeoForgeTPS.FindAllStringSubmatch(clean, -1) {
+			entries = append(entries, DimensionTPS{Name: m[1], TPS: pf(m[2]), MSPT: pf(m[3])})
+		}
+	}
+	if len(entries) == 0 {
+		return DimensionTPS{}, nil, responseError(input, "invalid forge tps output")
+	}
+
+	for _, e := range entries {
+		if strings.EqualFold(e.Name, "Overall") {
+			overall = e
+		} else {
+			dims = append(dims, e)
+		}
+	}
+
+	if overall.Name == "" {
+		// older versions print no overall line, use the worst dimension
+		overall = DimensionTPS{Name: "Overall", TPS: 20}
+		for _, d := range dims {
+			if d.MSPT > overall.MSPT {
+				overall.MSPT = d.MSPT
+				overall.TPS = d.TPS
+			}
+		}
+	}
+
+	return overall, dims, nil
+}
+
+type TickState string
+
+const (
+	TickRunning   TickState = "running"
+	TickLagging   TickState = "lagging"
+	TickFrozen    TickState = "frozen"
+	TickSprinting TickState = "sprinting"
+)
+
+// TickQuery is the parsed output of the vanilla `tick query` command (1.20.3+).
+type TickQuery struct {
+	State      TickState
+	TargetRate float64
+	AvgMSPT    float64
+	TargetMSPT float64
+	P50        float64
+	P95        float64
+	P99        float64
+	Samples    int
+}
+
+// TPS estimates the actual tick rate from the average tick time.
+func (q TickQuery) TPS() float64 {
+	if q.State == TickFrozen {
+		return 0
+	}
+	if q.AvgMSPT <= 0 {
+		return q.TargetRate
+	}
+	tps := 1000 / q.AvgMSPT
+	if q.State != TickSprinting && tps > q.TargetRate {
+		tps = q.TargetRate
+	}
+	return tps
+}
+
+var (
+	reTickRate        = regexp.MustCompile(`Target tick rate: ([\d.]+) per second`)
+	reTickAvg         = regexp.MustCompile(`Average time per tick: ([\d.]+)ms`)
+	reTickTarget      = regexp.MustCompile(`\(Target: ([\d.]+)ms\)`)
+	reTickPercentiles = regexp.MustCompile(`P50: ([\d.]+)ms P95: ([\d.]+)ms P99: ([\d.]+)ms, sample: (\d+)`)
+)
+
+func ParseTickQuery(input string) (TickQuery, error) {
+	clean := RemoveColorCodes(input)
+
+	var q TickQuery
+	switch {
+	case strings.Contains(clean, "The game is frozen"):
+		q.State = TickFrozen
+	case strings.Contains(clean, "The game is sprinting"):
+		q.State = TickSprinting
+	case strings.Contains(clean, "can't keep up"):
+		q.State = TickLagging
+	case strings.Contains(clean, "The game is running"):
+		q.State = TickRunning
+	}
+
+	m := reTickRate.FindStringSubmatch(clean)
+	if q.State == "" || m == nil {
+		return TickQuery{}, responseError(input, "invalid tick query output")
+	}
+	q.TargetRate, _ = strconv.ParseFloat(m[1], 64)
+
+	if m := reTickAvg.FindStringSubmatch(clean); m != nil {
+		q.AvgMSPT, _ = strconv.ParseFloat(m[1], 64)
+	}
+	if m := reTickTarget.FindStringSubmatch(clean); m != nil {
+		q.TargetMSPT, _ = strconv.ParseFloat(m[1], 64)
+	} else if q.TargetRate > 0 {
+		q.TargetMSPT = 1000 / q.TargetRate
+	}
+	if m := reTickPercentiles.FindStringSubmatch(clean); m != nil {
+		q.P50, _ = strconv.ParseFloat(m[1], 64)
+		q.P95, _ = strconv.ParseFloat(m[2], 64)
+		q.P99, _ = strconv.ParseFloat(m[3], 64)
+		q.Samples, _ = strconv.Atoi(m[4])
+	}
+
+	return q, nil
+}
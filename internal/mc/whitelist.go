@@ -0,0 +1,44 @@
+package mc
+
+import (
+	"regexp"
+	"strings"
+)
+
+var reWhitelist = regexp.MustCompile(`There are (\d+) whitelisted players?(?:\(s\))?:`)
+
+// ParseWhitelist parses `whitelist list`.
+func ParseWhitelist(resp string) ([]string, error) {
+	clean := RemoveColorCodes(resp)
+
+	if strings.Contains(clean, "There are no whitelisted players") {
+		return nil, nil
+	}
+
+	loc := reWhitelist.FindStringIndex(clean)
+	if loc == nil {
+		return nil, responseError(resp, "invalid whitelist output")
+	}
+
+	var names []string
+	for _, n := range strings.Split(clean[loc[1]:], ",") {
+		name := strings.TrimSpace(n)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// ParseWhitelistToggle parses the response of `whitelist on|off` and
+// returns the resulting state.
+func ParseWhitelistToggle(resp string) (enabled bool, err error) {
+	clean := RemoveColorCodes(resp)
+	switch {
+	case strings.Contains(clean, "turned on"):
+		return true, nil
+	case strings.Contains(clean, "turned off"):
+		return false, nil
+	}
+	return false, responseError(resp, "invalid whitelist toggle output")
+}
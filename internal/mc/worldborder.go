@@ -0,0 +1,70 @@
+package mc
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+type WorldBorder struct {
+	Size           float64
+	CenterX        float64
+	CenterZ        float64
+	DamagePerBlock float64
+	SafeZone       float64
+	WarningBlocks  float64
+	WarningTime    float64
+
+	// set while the border is moving
+	LerpTarget float64
+	LerpTimeMs int64
+}
+
+var reBorderSize = regexp.MustCompile(`currently ([\d.]+) block(?:\(s\)|s)? wide`)
+
+// ParseWorldBorderSize parses `worldborder get`.
+func ParseWorldBorderSize(resp string) (float64, error) {
+	m := reBorderSize.FindStringSubmatch(RemoveColorCodes(resp))
+	if m == nil {
+		return 0, responseError(resp, "invalid worldborder output")
+	}
+	return strconv.ParseFloat(m[1], 64)
+}
+
+type levelFile struct {
+	Data struct {
+		BorderCenterX        float64
+		BorderCenterZ        float64
+		BorderSize           float64
+		BorderSafeZone       float64
+		BorderDamagePerBlock float64
+		BorderWarningBlocks  float64
+		BorderWarningTime    float64
+		BorderSizeLerpTarget float64
+		BorderSizeLerpTime   int64
+
+		CustomBossEvents map[string]bossEvent
+	}
+}
+
+// ReadWorldBorder reads the border settings from level.dat. Vanilla has no
+// command to query the center or damage settings.
+func ReadWorldBorder(worldDir string) (WorldBorder, error) {
+	var f levelFile
+	if err := readGzipNBT(filepath.Join(worldDir, "level.dat"), &f); err != nil {
+		return WorldBorder{}, err
+	}
+
+	d := f.Data
+	return WorldBorder{
+		Size:           d.BorderSize,
+		CenterX:        d.BorderCenterX,
+		CenterZ:        d.BorderCenterZ,
+		DamagePerBlock: d.BorderDamagePerBlock,
+		SafeZone:       d.BorderSafeZone,
+		WarningBlocks:  d.BorderWarningBlocks,
+		WarningTime:    d.BorderWarningTime,
+		LerpTarget:     d.BorderSizeLerpTarget,
+		LerpTimeMs:     d.BorderSizeLerpTime,
+	}, nil
+}
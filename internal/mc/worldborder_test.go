@@ -0,0 +1,28 @@
+package mc
+
+import "testing"
+
+func TestParseWorldBorderSize(t *testing.T) {
+	tests := []struct {
+		resp string
+		want float64
+	}{
+		{"The world border is currently 59999968 block(s) wide", 59999968},
+		{"The world border is currently 1 block wide", 1},
+		{"The world border is currently 200.5 blocks wide", 200.5},
+	}
+	for _, tt := range tests {
+		got, err := ParseWorldBorderSize(tt.resp)
+		if err != nil {
+			t.Errorf("ParseWorldBorderSize(%q): %v", tt.resp, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWorldBorderSize(%q) = %v, want %v", tt.resp, got, tt.want)
+		}
+	}
+
+	if _, err := ParseWorldBorderSize("Unknown command"); err == nil {
+		t.Error("ParseWorldBorderSize accepted an unrelated response")
+	}
+}
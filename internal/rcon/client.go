@@ -1,8 +1,14 @@
 package rcon
 
-import "github.com/gorcon/rcon"
+import (
+	"sync"
 
+	"github.com/gorcon/rcon"
+)
+
+// Client is safe for concurrent use, commands are sent one at a time.
 type Client struct {
+	mu   sync.Mutex
 	conn *rcon.Conn
 }
 
@@ -16,6 +22,8 @@ func Connect(addr string, password string) (*Client, error) {
 }
 
 func (c *Client) Exec(cmd string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.conn.Execute(cmd)
 }
 
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type BansPanel struct {
+	table  Table
+	bans   []mc.Ban
+	loaded bool
+}
+
+func NewBansPanel() BansPanel {
+	return BansPanel{
+		table: NewTable(
+			Column{Title: "Target", Width: 16},
+			Column{Title: "Type", Width: 6},
+			Column{Title: "Source", Width: 10},
+			Column{Title: "Date", Width: 10},
+			Column{Title: "Reason"},
+		),
+	}
+}
+
+func (m *Model) FetchBans() {
+	var bans []mc.Ban
+
+	for _, ips := range []bool{false, true} {
+		// the ban files have dates and expiry which banlist does not print,
+		// and entries that cannot run into each other
+		if m.worldDir != "" {
+			if stored, err := mc.ReadBans(m.serverDir(), ips); err == nil {
+				bans = append(bans, stored...)
+				continue
+			}
+		}
+
+		cmd := "banlist players"
+		if ips {
+			cmd = "banlist ips"
+		}
+		resp, err := m.rcon.Exec(cmd)
+		if err != nil {
+			m.err = err
+			return
+		}
+		list, err := mc.ParseBanlist(resp, ips)
+		if err != nil {
+			m.err = err
+			return
+		}
+		bans = append(bans, list...)
+	}
+
+	sort.SliceStable(bans, func(i, j int) bool { return bans[i].Created.After(bans[j].Created) })
+	m.bans.bans = bans
+
+	rows := make([][]string, len(bans))
+	for i, b := range bans {
+		kind := "player"
+		if b.IP {
+			kind = "ip"
+		}
+		date := ""
+		if !b.Created.IsZero() {
+			date = b.Created.Format("2006-01-02")
+		}
+		target := b.Target
+		if b.Ambiguous {
+			target += "?"
+		}
+		rows[i] = []string{target, kind, b.Source, date, b.Reason}
+	}
+	m.bans.table.SetRows(rows)
+	m.bans.loaded = true
+}
+
+// askBanReason asks for an optional reason and confirms the final command.
+func (m *Model) askBanReason(command string, target string) {
+	m.AskReason("Reason for banning "+target+" (optional)", nil, func(m *Model, reason string) {
+		cmd := command + " " + target
+		if reason != "" {
+			cmd += " " + reason
+		}
+		m.AskExplicitConfirm("Ban "+target+"?", []string{cmd}, func(m *Model, resps []string) {
+			m.saveReason(reason)
+			refreshPanel(m, resps)
+		})
+	})
+	m.prompt.optional = true
+}
+
+func (m *Model) updateBansPanel(msg tea.KeyMsg) bool {
+	if m.bans.table.Update(msg) {
+		return true
+	}
+
+	switch msg.String() {
+	case "p", "delete":
+		i := m.bans.table.SelectedIndex()
+		if i < 0 {
+			return true
+		}
+		ban := m.bans.bans[i]
+		if ban.Ambiguous {
+			m.AppendLog("cannot tell where the name " + ban.Target + " starts, pardon it from the cmds tab")
+			return true
+		}
+		cmd := "pardon " + ban.Target
+		if ban.IP {
+			cmd = "pardon-ip " + ban.Target
+		}
+		m.AskExplicitConfirm("Pardon "+ban.Target+"?", []string{cmd}, refreshPanel)
+
+	case "b":
+		// works for offline players as well, the server resolves the profile
+		m.AskPrompt("Ban player", "nickname", func(m *Model, name string) {
+			m.askBanReason("ban", name)
+		})
+
+	case "i":
+		m.AskPrompt("Ban IP address (or online player's IP by name)", "127.0.0.1", func(m *Model, target string) {
+			if net.ParseIP(target) == nil && strings.ContainsAny(target, ".:") {
+				m.AppendLog("invalid IP address: " + target)
+				return
+			}
+			m.askBanReason("ban-ip", target)
+		})
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewBansPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Bans")
+
+	if !m.bans.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	table := m.bans.table
+	table.rowColor = func(row []string) string {
+		if row[1] == "ip" {
+			return m.colors.yellow
+		}
+		return ""
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		table.View(
+			width, height-3,
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[p] Pardon | [b] Ban player | [i] Ban IP | [r] Refresh"),
+	)
+}
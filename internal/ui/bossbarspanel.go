@@ -0,0 +1,494 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type bossbarCountdown struct {
+	label string
+	end   time.Time
+	// the name of the bar before the countdown, restored when it ends
+	name string
+}
+
+type BossbarsPanel struct {
+	bars []mc.Bossbar
+
+	// `bossbar list` only prints display names, the IDs come from level.dat
+	// and from bars created or edited here
+	known map[string]mc.Bossbar
+	// active bars whose ID is not known
+	unknown []string
+	// known IDs the last fetch found removed
+	removed []string
+
+	countdowns map[string]*bossbarCountdown
+
+	table       Table
+	loaded      bool
+	unsupported bool
+}
+
+// bossbarProperties are the `bossbar set` properties edited with a prompt,
+// by key.
+var bossbarProperties = map[string]struct {
+	name, label, placeholder string
+}{
+	"n": {"name", "Name", "Event"},
+	"v": {"value", "Value", "0"},
+	"m": {"max", "Maximum", "100"},
+	"C": {"color", "Color (" + strings.Join(mc.BossbarColors, ", ") + ")", "red"},
+	"s": {"style", "Style (" + strings.Join(mc.BossbarStyles, ", ") + ")", "progress"},
+	"p": {"players", "Show to players (selector or names, \"-\" for none)", "@a"},
+}
+
+func NewBossbarsPanel() BossbarsPanel {
+	return BossbarsPanel{
+		table: NewTable(
+			Column{Title: "ID"},
+			Column{Title: "Name"},
+			Column{Title: "Value", Width: 11},
+			Column{Title: "Color", Width: 7},
+			Column{Title: "Style", Width: 10},
+			Column{Title: "Shown", Width: 5},
+			Column{Title: "Players"},
+		),
+		known:      map[string]mc.Bossbar{},
+		countdowns: map[string]*bossbarCountdown{},
+	}
+}
+
+// bossbarID adds the default namespace the server would add.
+func bossbarID(id string) string {
+	if !strings.Contains(id, ":") {
+		return "minecraft:" + id
+	}
+	return id
+}
+
+func (m *Model) FetchBossbars() {
+	resp, err := m.rcon.Exec("bossbar list")
+	if err != nil {
+		m.err = err
+		return
+	}
+	names, err := mc.ParseBossbarList(resp)
+	if errors.Is(err, mc.ErrUnknownCommand) {
+		m.bossbars.unsupported = true
+		return
+	}
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	if m.worldDir != "" {
+		if stored, err := mc.ReadBossbars(m.worldDir); err == nil {
+			for _, b := range stored {
+				if _, ok := m.bossbars.known[b.ID]; !ok {
+					m.bossbars.known[b.ID] = b
+				}
+			}
+		}
+	}
+
+	var bars []mc.Bossbar
+	listed := map[string]bool{}
+	m.bossbars.removed = nil
+	for id, b := range m.bossbars.known {
+		bar, ok := m.fetchBossbar(b)
+		if !ok {
+			// removed since
+			delete(m.bossbars.known, id)
+			m.bossbars.removed = append(m.bossbars.removed, id)
+			continue
+		}
+		bars = append(bars, bar)
+		listed[bar.Name] = true
+	}
+	sort.Slice(bars, func(i, j int) bool { return bars[i].ID < bars[j].ID })
+	m.bossbars.bars = bars
+
+	m.bossbars.unknown = nil
+	for _, n := range names {
+		if !listed[n] {
+			m.bossbars.unknown = append(m.bossbars.unknown, n)
+		}
+	}
+
+	m.buildBossbarRows()
+	m.bossbars.loaded = true
+	m.bossbars.unsupported = false
+}
+
+// fetchBossbar reads the state the server can report for a bar. ok is false
+// when the bar does not exist.
+func (m *Model) fetchBossbar(b mc.Bossbar) (mc.Bossbar, bool) {
+	get := func(what string) string {
+		resp, err := m.rcon.Exec(fmt.Sprintf("bossbar get %s %s", b.ID, what))
+		if err != nil {
+			m.err = err
+		}
+		return resp
+	}
+
+	value, err := mc.ParseBossbarNumber(get("value"))
+	if err != nil {
+		return b, false
+	}
+	b.Value = value
+	if v, err := mc.ParseBossbarNumber(get("max")); err == nil {
+		b.Max = v
+	}
+	if v, err := mc.ParseBossbarVisible(get("visible")); err == nil {
+		b.Visible = v
+	}
+	if v, err := mc.ParseBossbarPlayers(get("players")); err == nil {
+		b.Players = v
+	}
+	return b, true
+}
+
+func (m *Model) buildBossbarRows() {
+	rows := make([][]string, 0, len(m.bossbars.bars)+len(m.bossbars.unknown))
+	for _, b := range m.bossbars.bars {
+		shown := "no"
+		if b.Visible {
+			shown = "yes"
+		}
+		rows = append(rows, []string{
+			b.ID, b.Name,
+			fmt.Sprintf("%d/%d", b.Value, b.Max),
+			orUnknown(b.Color), orUnknown(b.Style), shown,
+			strings.Join(b.Players, ", "),
+		})
+	}
+	for _, n := range m.bossbars.unknown {
+		rows = append(rows, []string{"?", n, "", "", "", "", ""})
+	}
+	m.bossbars.table.SetRows(rows)
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "?"
+	}
+	return s
+}
+
+// setBossbar changes a property of the bar and remembers it, the server
+// cannot be asked for the color, style or name.
+func (m *Model) setBossbar(id string, property string, value string) {
+	m.RunCmds([]string{strings.TrimSpace(fmt.Sprintf("bossbar set %s %s %s", id, property, value))}, func(m *Model, resps []string) {
+		if mc.ClassifyResponse(resps[0]) != nil {
+			return
+		}
+
+		b := m.bossbars.known[id]
+		switch property {
+		case "color":
+			b.Color = value
+		case "style":
+			b.Style = value
+		case "name":
+			if s, err := strconv.Unquote(value); err == nil {
+				b.Name = s
+				// restored when the running countdown ends
+				if c, ok := m.bossbars.countdowns[id]; ok {
+					c.name = s
+				}
+			}
+		}
+		m.bossbars.known[id] = b
+		refreshPanel(m, resps)
+	})
+}
+
+// TickBossbars advances the running countdowns. It runs on every tick, also
+// while the panel is not open.
+func (m *Model) TickBossbars() {
+	var cmds []string
+	for id, c := range m.bossbars.countdowns {
+		left := time.Until(c.end)
+		if left < 0 {
+			left = 0
+		}
+		secs := int(left.Round(time.Second).Seconds())
+
+		name := fmt.Sprintf("%s %s", c.label, formatCountdown(left))
+		if secs == 0 {
+			if c.name != "" {
+				name = c.name
+			}
+			delete(m.bossbars.countdowns, id)
+			m.AppendLog(fmt.Sprintf("countdown %s finished", id))
+		}
+		cmds = append(cmds,
+			fmt.Sprintf("bossbar set %s value %d", id, secs),
+			fmt.Sprintf("bossbar set %s name %s", id, strconv.Quote(name)),
+		)
+		m.setBossbarName(id, name)
+		for i := range m.bossbars.bars {
+			if m.bossbars.bars[i].ID == id {
+				m.bossbars.bars[i].Value = secs
+			}
+		}
+	}
+	if len(cmds) > 0 {
+		m.buildBossbarRows()
+		m.queue(m.execCmd(cmds, nil, true))
+	}
+}
+
+// setBossbarName updates the name shown for a bar.
+func (m *Model) setBossbarName(id string, name string) {
+	b := m.bossbars.known[id]
+	b.Name = name
+	m.bossbars.known[id] = b
+	for i := range m.bossbars.bars {
+		if m.bossbars.bars[i].ID == id {
+			m.bossbars.bars[i].Name = name
+		}
+	}
+}
+
+// stopBossbarCountdown stops a countdown and gives the bar its name back.
+func (m *Model) stopBossbarCountdown(id string) {
+	c, ok := m.bossbars.countdowns[id]
+	if !ok {
+		return
+	}
+	delete(m.bossbars.countdowns, id)
+	m.AppendLog(fmt.Sprintf("countdown %s stopped", id))
+	if c.name != "" {
+		m.setBossbar(id, "name", strconv.Quote(c.name))
+	}
+}
+
+// bossbarName returns the name of a bar, the one before its countdown while
+// one is running.
+func (m Model) bossbarName(id string) string {
+	if c, ok := m.bossbars.countdowns[id]; ok {
+		return c.name
+	}
+	for _, b := range m.bossbars.bars {
+		if b.ID == id && b.Name != "" {
+			return b.Name
+		}
+	}
+	return m.bossbars.known[id].Name
+}
+
+func (m *Model) startBossbarCountdown(id string, seconds int, label string) {
+	name := m.bossbarName(id)
+	cmds := []string{
+		fmt.Sprintf("bossbar set %s max %d", id, seconds),
+		fmt.Sprintf("bossbar set %s value %d", id, seconds),
+		fmt.Sprintf("bossbar set %s visible true", id),
+	}
+	m.RunCmds(cmds, func(m *Model, resps []string) {
+		for _, r := range resps {
+			if mc.ClassifyResponse(r) != nil {
+				return
+			}
+		}
+		m.bossbars.countdowns[id] = &bossbarCountdown{
+			label: label,
+			end:   time.Now().Add(time.Duration(seconds) * time.Second),
+			name:  name,
+		}
+		refreshPanel(m, resps)
+	})
+}
+
+func (m *Model) updateBossbarsPanel(msg tea.KeyMsg) bool {
+	if m.bossbars.unsupported {
+		return false
+	}
+	if m.bossbars.table.Update(msg) {
+		return true
+	}
+
+	row, hasRow := m.bossbars.table.Selected()
+	id := ""
+	if hasRow && row[0] != "?" {
+		id = row[0]
+	}
+
+	switch msg.String() {
+	case "c":
+		m.AskPrompt("Create bossbar: <id> <name>", "event Event starts soon", func(m *Model, value string) {
+			parts := strings.SplitN(strings.TrimSpace(value), " ", 2)
+			if len(parts) != 2 {
+				m.err = fmt.Errorf("expected <id> <name>, got %q", value)
+				return
+			}
+			id := bossbarID(parts[0])
+			m.RunCmds([]string{fmt.Sprintf("bossbar add %s %s", id, strconv.Quote(parts[1]))}, func(m *Model, resps []string) {
+				if mc.ClassifyResponse(resps[0]) == nil {
+					m.bossbars.known[id] = mc.Bossbar{ID: id, Name: parts[1], Color: "white", Style: "progress"}
+				}
+				refreshPanel(m, resps)
+			})
+		})
+
+	case "n", "v", "m", "C", "s", "p":
+		if id == "" {
+			return true
+		}
+		p := bossbarProperties[msg.String()]
+		m.AskPrompt(p.label+" of "+id, p.placeholder, func(m *Model, value string) {
+			switch p.name {
+			case "name":
+				value = strconv.Quote(value)
+			case "value", "max":
+				if _, err := strconv.Atoi(value); err != nil {
+					m.err = fmt.Errorf("invalid %s %q", p.name, value)
+					return
+				}
+			case "players":
+				// `bossbar set <id> players` without targets hides it from everyone
+				if value == "-" {
+					value = ""
+				}
+			}
+			m.setBossbar(id, p.name, value)
+		})
+
+	case "h":
+		if id == "" {
+			return true
+		}
+		visible := "true"
+		if row[5] == "yes" {
+			visible = "false"
+		}
+		m.setBossbar(id, "visible", visible)
+
+	case "t":
+		if id == "" {
+			return true
+		}
+		m.AskPrompt("Countdown on "+id+": <seconds> [label]", "300 Event starts in", func(m *Model, value string) {
+			parts := strings.SplitN(strings.TrimSpace(value), " ", 2)
+			seconds, err := strconv.Atoi(parts[0])
+			if err != nil || seconds <= 0 {
+				m.err = fmt.Errorf("invalid countdown length %q", parts[0])
+				return
+			}
+			label := m.bossbarName(id)
+			if len(parts) == 2 {
+				label = parts[1]
+			}
+			m.startBossbarCountdown(id, seconds, label)
+		})
+
+	case "T":
+		if id == "" {
+			return true
+		}
+		m.stopBossbarCountdown(id)
+
+	case "x", "delete":
+		if id == "" {
+			return true
+		}
+		m.AskConfirm("Remove bossbar "+id+"?", []string{"bossbar remove " + id}, func(m *Model, resps []string) {
+			if mc.ClassifyResponse(resps[0]) == nil {
+				delete(m.bossbars.known, id)
+				delete(m.bossbars.countdowns, id)
+			}
+			refreshPanel(m, resps)
+		})
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewBossbarsPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Bossbars")
+
+	if m.bossbars.unsupported {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			title,
+			lipgloss.NewStyle().
+				Foreground(lipgloss.Color(m.colors.yellow)).
+				Render("This server has no /bossbar command (requires 1.13+)."),
+		)
+	}
+	if !m.bossbars.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	// progress of the selected bar, with the countdown when one is running
+	var detail string
+	if i := m.bossbars.table.SelectedIndex(); i >= 0 && i < len(m.bossbars.bars) {
+		b := m.bossbars.bars[i]
+		color := m.colors.text()
+		if c, ok := m.mcColor(b.Color); ok {
+			color = c
+		} else if b.Color == "pink" || b.Color == "purple" {
+			color, _ = m.mcColor("light_purple")
+		}
+		ratio := 0.0
+		if b.Max > 0 {
+			ratio = float64(b.Value) / float64(b.Max)
+		}
+		detail = lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(AsciiBar(ratio, width-20, "█", "░"))
+		if c, ok := m.bossbars.countdowns[b.ID]; ok {
+			detail += lipgloss.NewStyle().Bold(true).Render(" " + formatCountdown(time.Until(c.end)))
+		}
+	}
+
+	var notes []string
+	if len(m.bossbars.unknown) > 0 {
+		notes = append(notes, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.yellow)).
+			Width(width).
+			Render(fmt.Sprintf("%d active bars with unknown IDs, pass -world to read them from level.dat", len(m.bossbars.unknown))))
+	}
+
+	table := m.bossbars.table
+	table.rowColor = func(row []string) string {
+		if _, ok := m.bossbars.countdowns[row[0]]; ok {
+			return m.colors.yellow
+		}
+		if row[0] == "?" || row[5] == "no" {
+			return m.colors.textDimmed()
+		}
+		return ""
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		table.View(
+			width, height-5-len(notes),
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.JoinVertical(lipgloss.Top, notes...),
+		detail,
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Width(width).
+			Render("[c] Create | [n] Name | [v/m] Value/max | [C] Color | [s] Style | [h] Show/hide | [p] Players | [t/T] Countdown/stop | [x] Remove"),
+	)
+}
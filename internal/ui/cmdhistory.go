@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+
+	"sebpok/mc-rcon-tui/internal/history"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CmdHistory is the state of browsing and searching the command history in
+// the cmds tab.
+type CmdHistory struct {
+	// index of the entry shown while browsing with up/down, -1 otherwise
+	index int
+	// input typed before browsing, shown again after the newest entry
+	draft string
+
+	searching bool
+	query     string
+	// -1 when nothing matches
+	match int
+}
+
+// SetHistory sets the history used by the cmds tab.
+func (m *Model) SetHistory(h *history.History) {
+	m.history = h
+}
+
+func (m *Model) showHistoryEntry(cmd string) {
+	m.input.SetValue(cmd)
+	m.input.CursorEnd()
+	m.completion.suggestions = nil
+}
+
+// recordCommand adds a command run from the cmds tab to the history.
+func (m *Model) recordCommand(cmd string) {
+	m.cmdHistory.index = -1
+	if m.history == nil {
+		return
+	}
+	if err := m.history.Add(cmd); err != nil {
+		m.err = err
+	}
+}
+
+// updateHistory handles the history keys of the cmds input: up/down browse
+// it, ctrl+r starts an incremental search backwards.
+func (m *Model) updateHistory(msg tea.KeyMsg) bool {
+	if m.history == nil {
+		return false
+	}
+	h := &m.cmdHistory
+
+	if h.searching {
+		switch msg.String() {
+		case "ctrl+r":
+			from := m.history.Len()
+			if h.match >= 0 {
+				from = h.match
+			}
+			if i := m.history.Search(h.query, from); i >= 0 {
+				h.match = i
+			}
+		case "esc", "ctrl+c", "ctrl+g":
+			h.searching = false
+		case "backspace":
+			if r := []rune(h.query); len(r) > 0 {
+				h.query = string(r[:len(r)-1])
+			}
+			h.match = m.history.Search(h.query, m.history.Len())
+		case "enter":
+			h.searching = false
+			if h.match >= 0 {
+				m.showHistoryEntry(m.history.At(h.match))
+			}
+		default:
+			if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+				// leave the search with the match, the key does its usual job
+				h.searching = false
+				if h.match >= 0 {
+					m.showHistoryEntry(m.history.At(h.match))
+				}
+				return false
+			}
+			h.query += string(msg.Runes)
+			h.match = m.history.Search(h.query, m.history.Len())
+		}
+		return true
+	}
+
+	switch msg.String() {
+	case "ctrl+r":
+		h.searching = true
+		h.query = ""
+		h.match = -1
+	case "up":
+		if h.index == -1 {
+			h.draft = m.input.Value()
+			h.index = m.history.Len()
+		}
+		if h.index > 0 {
+			h.index--
+			m.showHistoryEntry(m.history.At(h.index))
+		}
+	case "down":
+		if h.index == -1 {
+			return true
+		}
+		h.index++
+		if h.index >= m.history.Len() {
+			h.index = -1
+			m.showHistoryEntry(h.draft)
+		} else {
+			m.showHistoryEntry(m.history.At(h.index))
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// viewHistorySearch renders the input line while searching the history.
+func (m Model) viewHistorySearch() string {
+	prompt := "(reverse-i-search)"
+	match := ""
+	if m.cmdHistory.match >= 0 {
+		match = m.history.At(m.cmdHistory.match)
+	} else if m.cmdHistory.query != "" {
+		prompt = "(failed reverse-i-search)"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.textDimmed())).Render(fmt.Sprintf("%s'%s': ", prompt, m.cmdHistory.query)) + match
+}
+
+func (m Model) historyHint() string {
+	if m.history == nil || m.tabs[m.tabActiveIndex] != "cmds" {
+		return ""
+	}
+	return " | [up/down] History | [ctrl+r] Search"
+}
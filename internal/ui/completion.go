@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxSuggestionsShown = 8
+
+// Completion suggests commands, arguments and values for the cmds input.
+type Completion struct {
+	tree *mc.CommandTree
+	// commands whose `help <command>` was asked for, `help` alone only lists
+	// the first level of every command
+	detailed map[string]bool
+
+	items  []string
+	blocks []string
+
+	suggestions []suggestion
+	selected    int
+	// byte offset of the word the suggestions replace
+	start int
+}
+
+type suggestion struct {
+	text string
+	// only names the expected argument, e.g. "<count>"
+	hint bool
+}
+
+// LoadCommands learns the command tree from the commands.json report when
+// the server directory has one, otherwise from `help`.
+func (m *Model) LoadCommands() {
+	c := &m.completion
+	c.blocks = mc.BlockIDs
+	c.items = append(append([]string{}, mc.BlockIDs...), mc.ItemIDs...)
+
+	if m.worldDir != "" {
+		if t, err := mc.ReadCommandsReport(m.serverDir()); err == nil {
+			c.tree = t
+		}
+		if items, blocks, err := mc.ReadRegistryIDs(m.serverDir()); err == nil {
+			c.items, c.blocks = items, blocks
+		}
+	}
+	if c.tree != nil {
+		return
+	}
+
+	resp, err := m.rcon.Exec("help")
+	if err != nil {
+		m.err = err
+		return
+	}
+	t := mc.NewCommandTree()
+	t.AddUsage(resp)
+	c.tree = t
+}
+
+func (m *Model) LoadCommandsCmd() tea.Cmd {
+	if m.completion.tree != nil {
+		return nil
+	}
+	return m.fetch("commands", (*Model).LoadCommands, func(m *Model, r *Model) {
+		m.completion.tree = r.completion.tree
+		m.completion.items = r.completion.items
+		m.completion.blocks = r.completion.blocks
+		m.completion.detailed = map[string]bool{}
+	})
+}
+
+// askCommandHelp fetches the full usage of a command the first time it is
+// typed.
+func (m *Model) askCommandHelp(cmd string) {
+	c := &m.completion
+	if c.tree.Complete || c.detailed[cmd] || c.tree.Command(cmd) == nil {
+		return
+	}
+	c.detailed[cmd] = true
+	m.queue(m.execCmd([]string{"help " + cmd}, func(m *Model, resps []string) {
+		m.completion.tree.AddUsage(resps[0])
+		m.updateSuggestions()
+	}, true))
+}
+
+// argValues returns the values suggested for a node of the command tree.
+func (m Model) argValues(n *mc.CommandNode) []string {
+	if n.Literal {
+		return []string{n.Name}
+	}
+
+	switch n.Kind {
+	case mc.ArgEntity:
+		var values []string
+		for _, item := range m.players.Items() {
+			values = append(values, item.(playerItem).Name)
+		}
+		return append(values, "@a", "@p", "@r", "@s", "@e")
+	case mc.ArgItem:
+		return m.completion.items
+	case mc.ArgBlock:
+		return m.completion.blocks
+	case mc.ArgPos:
+		values := []string{"~ ~ ~", "^ ^ ^"}
+		// where the player shown in the popup stands
+		if p := m.popup.player; p.Nickname != "" && p.Dimension != "" {
+			values = append(values, fmt.Sprintf("%d %d %d",
+				int(math.Floor(p.Pos.X)), int(math.Floor(p.Pos.Y)), int(math.Floor(p.Pos.Z))))
+		}
+		return values
+	case mc.ArgPos2:
+		return []string{"~ ~"}
+	}
+	return nil
+}
+
+// updateSuggestions completes the word before the cursor.
+func (m *Model) updateSuggestions() {
+	c := &m.completion
+	c.suggestions, c.selected = nil, 0
+	if c.tree == nil {
+		return
+	}
+
+	line := string([]rune(m.input.Value())[:m.input.Position()])
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	words := mc.CommandWords(line)
+	partial := words[len(words)-1]
+	c.start = len(line) - len(partial)
+	if len(words) > 1 {
+		m.askCommandHelp(words[0])
+	}
+
+	// IDs may be typed with or without the namespace
+	namespace := ""
+	if strings.HasPrefix(partial, "minecraft:") {
+		namespace = "minecraft:"
+	}
+
+	var hints []suggestion
+	seen := map[string]bool{}
+	for _, n := range c.tree.Next(words[:len(words)-1]) {
+		for _, v := range m.argValues(n) {
+			if (n.Kind == mc.ArgItem || n.Kind == mc.ArgBlock) && !n.Literal {
+				v = namespace + v
+			}
+			if seen[v] || v == partial || !strings.HasPrefix(strings.ToLower(v), strings.ToLower(partial)) {
+				continue
+			}
+			seen[v] = true
+			c.suggestions = append(c.suggestions, suggestion{text: v})
+		}
+		if hint := "<" + n.Name + ">"; !n.Literal && !seen[hint] {
+			seen[hint] = true
+			hints = append(hints, suggestion{text: hint, hint: true})
+		}
+	}
+	c.suggestions = append(c.suggestions, hints...)
+}
+
+// acceptSuggestion replaces the word before the cursor with the selected
+// suggestion.
+func (m *Model) acceptSuggestion() bool {
+	c := &m.completion
+	if c.selected >= len(c.suggestions) || c.suggestions[c.selected].hint {
+		return false
+	}
+
+	value := []rune(m.input.Value())
+	line := string(value[:m.input.Position()])
+	rest := string(value[m.input.Position():])
+
+	completed := line[:c.start] + c.suggestions[c.selected].text + " "
+	m.input.SetValue(completed + rest)
+	m.input.SetCursor(len([]rune(completed)))
+	m.updateSuggestions()
+	return true
+}
+
+// updateCompletion handles the keys of the suggestion popup: tab, or right
+// at the end of the input, accepts, ctrl+n/ctrl+p move the selection and esc
+// closes it. While it is closed tab switches tabs.
+func (m *Model) updateCompletion(msg tea.KeyMsg) bool {
+	c := &m.completion
+	if len(c.suggestions) == 0 {
+		return false
+	}
+
+	switch msg.String() {
+	case "tab":
+		return m.acceptSuggestion()
+	case "right":
+		if m.input.Position() < len([]rune(m.input.Value())) {
+			return false
+		}
+		return m.acceptSuggestion()
+	case "ctrl+n", "ctrl+p":
+		n := len(c.suggestions)
+		for n > 0 && c.suggestions[n-1].hint {
+			n--
+		}
+		if n == 0 {
+			return true
+		}
+		if msg.String() == "ctrl+n" {
+			c.selected = (c.selected + 1) % n
+		} else {
+			c.selected = (c.selected - 1 + n) % n
+		}
+	case "esc":
+		c.suggestions = nil
+	default:
+		return false
+	}
+	return true
+}
+
+// viewSuggestions draws the suggestion popup over the last lines of content.
+func (m Model) viewSuggestions(content string, width int) string {
+	c := m.completion
+	if len(c.suggestions) == 0 {
+		return content
+	}
+
+	first := max(0, min(c.selected-maxSuggestionsShown/2, len(c.suggestions)-maxSuggestionsShown))
+	last := min(len(c.suggestions), first+maxSuggestionsShown)
+
+	dimmed := lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.textDimmed()))
+	popup := []string{dimmed.Render("[tab/→] Complete | [ctrl+n/p] Select | [esc] Close")}
+	for i := first; i < last; i++ {
+		s := c.suggestions[i]
+		style := lipgloss.NewStyle().Width(width).MaxHeight(1)
+		switch {
+		case s.hint:
+			style = style.Foreground(lipgloss.Color(m.colors.textDimmed()))
+		case i == c.selected:
+			style = m.styles.playerLabelSelected.Width(width).MaxHeight(1)
+		}
+		popup = append(popup, style.Render(s.text))
+	}
+	if last-first < len(c.suggestions) {
+		popup = append(popup, dimmed.Render(fmt.Sprintf("%d/%d", c.selected+1, len(c.suggestions))))
+	}
+
+	lines := strings.Split(content, "\n")
+	if len(popup) > len(lines) {
+		popup = popup[len(popup)-len(lines):]
+	}
+	copy(lines[len(lines)-len(popup):], popup)
+	return strings.Join(lines, "\n")
+}
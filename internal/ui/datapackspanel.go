@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type DatapacksPanel struct {
+	// enabled packs in load order, then the available ones
+	packs   []mc.Datapack
+	enabled int
+
+	// responses of the last change, shown under the table
+	feedback []string
+
+	table       Table
+	loaded      bool
+	unsupported bool
+}
+
+func NewDatapacksPanel() DatapacksPanel {
+	return DatapacksPanel{
+		table: NewTable(
+			Column{Title: "#", Width: 3},
+			Column{Title: "Name"},
+			Column{Title: "Source", Width: 12},
+			Column{Title: "Status", Width: 8},
+		),
+	}
+}
+
+func (m *Model) FetchDatapacks() {
+	var packs []mc.Datapack
+	for _, state := range []string{"enabled", "available"} {
+		resp, err := m.rcon.Exec("datapack list " + state)
+		if err != nil {
+			m.err = err
+			return
+		}
+		list, err := mc.ParseDatapackList(resp, state == "enabled")
+		if errors.Is(err, mc.ErrUnknownCommand) {
+			m.datapacks.unsupported = true
+			return
+		}
+		if err != nil {
+			m.err = err
+			return
+		}
+		if state == "enabled" {
+			m.datapacks.enabled = len(list)
+		}
+		packs = append(packs, list...)
+	}
+	m.datapacks.packs = packs
+
+	rows := make([][]string, len(packs))
+	for i, p := range packs {
+		order, status := "", "disabled"
+		if p.Enabled {
+			order, status = strconv.Itoa(i+1), "enabled"
+		}
+		rows[i] = []string{order, p.Name, p.Source, status}
+	}
+	m.datapacks.table.SetRows(rows)
+	m.datapacks.loaded = true
+	m.datapacks.unsupported = false
+}
+
+// datapackFeedback keeps the responses of a change to show them inline.
+func datapackFeedback(m *Model, resps []string) {
+	m.datapacks.feedback = nil
+	for _, r := range resps {
+		if r = strings.TrimSpace(mc.RemoveColorCodes(r)); r != "" {
+			m.datapacks.feedback = append(m.datapacks.feedback, r)
+		}
+	}
+	refreshPanel(m, resps)
+}
+
+// runDatapackCmds executes the commands without confirmation and shows their
+// feedback.
+func (m *Model) runDatapackCmds(cmds ...string) {
+	m.RunCmds(cmds, datapackFeedback)
+}
+
+func (m *Model) updateDatapacksPanel(msg tea.KeyMsg) bool {
+	if m.datapacks.unsupported {
+		return false
+	}
+	if m.datapacks.table.Update(msg) {
+		return true
+	}
+
+	i := m.datapacks.table.SelectedIndex()
+	var pack mc.Datapack
+	if i >= 0 {
+		pack = m.datapacks.packs[i]
+	}
+	arg := mc.DatapackArg(pack.Name)
+
+	switch msg.String() {
+	case "enter", " ":
+		if i < 0 {
+			return true
+		}
+		if pack.Enabled {
+			m.AskConfirm("Disable "+pack.Name+"? The server reloads its data.", []string{"datapack disable " + arg}, datapackFeedback)
+		} else {
+			m.runDatapackCmds("datapack enable " + arg)
+		}
+
+	case "K", "shift+up", "J", "shift+down":
+		// only enabled packs have an order, moving one is disable + enable
+		// next to its neighbour
+		if i < 0 || !pack.Enabled {
+			return true
+		}
+		// the neighbours may be hidden, the cursor moves over the visible
+		// rows only
+		if m.datapacks.table.Filtered() {
+			m.err = fmt.Errorf("clear the search to reorder datapacks")
+			return true
+		}
+		up := msg.String() == "K" || msg.String() == "shift+up"
+		switch {
+		case up && i > 0:
+			m.runDatapackCmds("datapack disable "+arg, "datapack enable "+arg+" before "+mc.DatapackArg(m.datapacks.packs[i-1].Name))
+			m.datapacks.table.cursor--
+		case !up && i < m.datapacks.enabled-1:
+			m.runDatapackCmds("datapack disable "+arg, "datapack enable "+arg+" after "+mc.DatapackArg(m.datapacks.packs[i+1].Name))
+			m.datapacks.table.cursor++
+		}
+
+	case "i":
+		if m.worldDir == "" {
+			m.err = fmt.Errorf("installing datapacks needs the world directory (-world)")
+			return true
+		}
+		m.AskPrompt("Install datapack zip or folder from", "/path/to/pack.zip", func(m *Model, path string) {
+			name, err := mc.InstallDatapack(m.worldDir, path)
+			if err != nil {
+				m.err = err
+				return
+			}
+			m.AppendLog("installed " + name)
+
+			m.RunCmds([]string{"reload", "datapack list enabled"}, func(m *Model, resps []string) {
+				feedback := []string{"installed " + name, resps[0]}
+
+				// new packs are usually enabled by the reload
+				enabled, _ := mc.ParseDatapackList(resps[1], true)
+				for _, p := range enabled {
+					if p.Name == name {
+						datapackFeedback(m, feedback)
+						return
+					}
+				}
+				m.RunCmds([]string{"datapack enable " + mc.DatapackArg(name)}, func(m *Model, resps []string) {
+					datapackFeedback(m, append(feedback, resps...))
+				})
+			})
+		})
+
+	case "r":
+		m.datapacks.feedback = nil
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewDatapacksPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Datapacks")
+
+	if m.datapacks.unsupported {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			title,
+			lipgloss.NewStyle().
+				Foreground(lipgloss.Color(m.colors.yellow)).
+				Render("This server has no /datapack command (requires 1.13+)."),
+		)
+	}
+	if !m.datapacks.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Width(width/2).Render(title),
+		lipgloss.NewStyle().
+			Width(width-width/2).
+			Align(lipgloss.Right).
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(fmt.Sprintf("%d enabled, %d available", m.datapacks.enabled, len(m.datapacks.packs)-m.datapacks.enabled)),
+	)
+
+	var feedback []string
+	for _, f := range m.datapacks.feedback {
+		color := m.colors.green
+		if mc.ClassifyResponse(f) != nil {
+			color = m.colors.red
+		}
+		feedback = append(feedback, lipgloss.NewStyle().
+			Width(width).
+			MaxHeight(1).
+			Foreground(lipgloss.Color(color)).
+			Render(f))
+	}
+
+	table := m.datapacks.table
+	table.rowColor = func(row []string) string {
+		if row[3] == "disabled" {
+			return m.colors.textDimmed()
+		}
+		return ""
+	}
+
+	help := "[enter] Enable/disable | [K/J] Move up/down | [r] Refresh"
+	if m.worldDir != "" {
+		help = "[enter] Enable/disable | [K/J] Move up/down | [i] Install | [r] Refresh"
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		table.View(
+			width, height-3-len(feedback),
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.JoinVertical(lipgloss.Top, feedback...),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(help),
+	)
+}
@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/history"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxPresetsShown = 6
+
+// Confirm is a yes/no dialog shown before commands that change server state.
+type Confirm struct {
+	shown bool
+	text  string
+	cmds  []string
+	after func(m *Model, resps []string)
+	// only "y" confirms, enter pressed once too often does not
+	explicit bool
+}
+
+// Prompt asks for a single value and passes it to onSubmit.
+type Prompt struct {
+	shown    bool
+	label    string
+	input    textinput.Model
+	onSubmit func(m *Model, value string)
+	// an empty value is submitted rather than ignored
+	optional bool
+
+	// values picked with up/down, -1 while none is picked
+	presets []string
+	preset  int
+}
+
+// Choice asks to pick one of a few values and passes it to onSubmit.
+type Choice struct {
+	shown    bool
+	label    string
+	choices  []string
+	index    int
+	onSubmit func(m *Model, value string)
+}
+
+// AskConfirm shows text and the commands to be sent. After confirmation the
+// commands are executed and after is called with their responses.
+func (m *Model) AskConfirm(text string, cmds []string, after func(m *Model, resps []string)) {
+	m.confirm = &Confirm{
+		shown: true,
+		text:  text,
+		cmds:  cmds,
+		after: after,
+	}
+}
+
+// AskExplicitConfirm is AskConfirm for moderation commands, it is only
+// confirmed with "y".
+func (m *Model) AskExplicitConfirm(text string, cmds []string, after func(m *Model, resps []string)) {
+	m.AskConfirm(text, cmds, after)
+	m.confirm.explicit = true
+}
+
+func (m *Model) AskPrompt(label string, placeholder string, onSubmit func(m *Model, value string)) {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.Prompt = "> "
+	ti.CharLimit = 200
+	ti.Width = 40
+	ti.Focus()
+
+	m.prompt = &Prompt{
+		shown:    true,
+		label:    label,
+		input:    ti,
+		onSubmit: onSubmit,
+		preset:   -1,
+	}
+}
+
+// SetReasons sets where the reasons given for kicks and bans are saved.
+func (m *Model) SetReasons(h *history.History) {
+	m.reasons = h
+}
+
+// saveReason keeps a reason for the next AskReason, call it once the
+// command with the reason was sent.
+func (m *Model) saveReason(reason string) {
+	if m.reasons == nil || reason == "" {
+		return
+	}
+	if err := m.reasons.Add(reason); err != nil {
+		m.err = err
+	}
+}
+
+// AskReason asks for a reason, offering presets and the reasons given
+// before, newest first. See saveReason.
+func (m *Model) AskReason(label string, presets []string, onSubmit func(m *Model, reason string)) {
+	m.AskPrompt(label, "reason", onSubmit)
+
+	seen := map[string]bool{}
+	add := func(reason string) {
+		if !seen[reason] {
+			seen[reason] = true
+			m.prompt.presets = append(m.prompt.presets, reason)
+		}
+	}
+	for _, reason := range presets {
+		add(reason)
+	}
+	if m.reasons != nil {
+		for i := m.reasons.Len() - 1; i >= 0; i-- {
+			add(m.reasons.At(i))
+		}
+	}
+}
+
+func (m *Model) AskChoice(label string, choices []string, onSubmit func(m *Model, value string)) {
+	m.choice = &Choice{
+		shown:    true,
+		label:    label,
+		choices:  choices,
+		onSubmit: onSubmit,
+	}
+}
+
+func (m *Model) updateDialogs(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.confirm != nil && m.confirm.shown {
+		switch msg.String() {
+		case "y", "Y", "enter":
+			if msg.String() == "enter" && m.confirm.explicit {
+				return true, nil
+			}
+			c := m.confirm
+			m.confirm = nil
+			m.RunCmds(c.cmds, c.after)
+		case "n", "N", "esc", "ctrl+c":
+			m.confirm = nil
+		}
+		return true, nil
+	}
+
+	if m.prompt != nil && m.prompt.shown {
+		p := m.prompt
+		switch msg.String() {
+		case "up", "down":
+			// up above the first preset goes back to an empty input
+			if len(p.presets) == 0 || (msg.String() == "up" && p.preset == -1) {
+				return true, nil
+			}
+			if msg.String() == "up" {
+				p.preset = max(p.preset-1, -1)
+			} else {
+				p.preset = min(p.preset+1, len(p.presets)-1)
+			}
+			if p.preset == -1 {
+				p.input.SetValue("")
+			} else {
+				p.input.SetValue(p.presets[p.preset])
+			}
+			p.input.CursorEnd()
+			return true, nil
+		case "enter":
+			m.prompt = nil
+			value := strings.TrimSpace(p.input.Value())
+			if (value != "" || p.optional) && p.onSubmit != nil {
+				p.onSubmit(m, value)
+			}
+			return true, nil
+		case "esc", "ctrl+c":
+			m.prompt = nil
+			return true, nil
+		}
+		var cmd tea.Cmd
+		m.prompt.input, cmd = m.prompt.input.Update(msg)
+		return true, cmd
+	}
+
+	if m.choice != nil && m.choice.shown {
+		c := m.choice
+		switch msg.String() {
+		case "up", "k", "left", "h":
+			c.index = (c.index - 1 + len(c.choices)) % len(c.choices)
+		case "down", "j", "right", "l":
+			c.index = (c.index + 1) % len(c.choices)
+		case "enter":
+			m.choice = nil
+			if c.onSubmit != nil {
+				c.onSubmit(m, c.choices[c.index])
+			}
+		case "esc", "ctrl+c":
+			m.choice = nil
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// viewDialog renders the active dialog, or "" if none is shown.
+func (m Model) viewDialog() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.borderColorActive).
+		Padding(1, 2).
+		Width(m.popup.width)
+
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.colors.textDimmed()))
+
+	submitHint := "[enter] Submit"
+	if m.prompt != nil && m.prompt.optional {
+		submitHint = "[enter] Submit, empty for none"
+	}
+
+	switch {
+	case m.confirm != nil && m.confirm.shown:
+		confirmHint := "[y/enter] Confirm | [n/esc] Cancel"
+		if m.confirm.explicit {
+			confirmHint = "[y] Confirm | [n/esc] Cancel"
+		}
+		var cmds []string
+		for _, c := range m.confirm.cmds {
+			cmds = append(cmds, "/"+c)
+		}
+		return box.Render(lipgloss.JoinVertical(
+			lipgloss.Top,
+			lipgloss.NewStyle().Bold(true).Render(m.confirm.text),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.yellow)).Render(strings.Join(cmds, "\n")),
+			hint.MarginTop(1).Render(confirmHint),
+		))
+
+	case m.prompt != nil && m.prompt.shown:
+		p := m.prompt
+		if len(p.presets) == 0 {
+			return box.Render(lipgloss.JoinVertical(
+				lipgloss.Top,
+				lipgloss.NewStyle().Bold(true).Render(p.label),
+				p.input.View(),
+				hint.MarginTop(1).Render(submitHint+" | [esc] Cancel"),
+			))
+		}
+
+		first := max(0, min(p.preset-maxPresetsShown/2, len(p.presets)-maxPresetsShown))
+		last := min(len(p.presets), first+maxPresetsShown)
+		var lines []string
+		for i := first; i < last; i++ {
+			if i == p.preset {
+				lines = append(lines, m.styles.playerLabelSelected.Render("  "+p.presets[i]))
+			} else {
+				lines = append(lines, hint.Render("  "+p.presets[i]))
+			}
+		}
+		return box.Render(lipgloss.JoinVertical(
+			lipgloss.Top,
+			lipgloss.NewStyle().Bold(true).Render(p.label),
+			p.input.View(),
+			lipgloss.NewStyle().MarginTop(1).Render(strings.Join(lines, "\n")),
+			hint.MarginTop(1).Render("[up/down] Presets | "+submitHint+" | [esc] Cancel"),
+		))
+
+	case m.choice != nil && m.choice.shown:
+		var lines []string
+		for i, c := range m.choice.choices {
+			if i == m.choice.index {
+				lines = append(lines, m.styles.playerLabelSelected.Render("> "+c))
+			} else {
+				lines = append(lines, "  "+c)
+			}
+		}
+		return box.Render(lipgloss.JoinVertical(
+			lipgloss.Top,
+			lipgloss.NewStyle().Bold(true).Render(m.choice.label),
+			strings.Join(lines, "\n"),
+			hint.MarginTop(1).Render("[up/down] Select | [enter] Submit | [esc] Cancel"),
+		))
+	}
+
+	return ""
+}
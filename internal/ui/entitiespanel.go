@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var dimensionTitles = map[string]string{
+	"overworld":  "Overworld",
+	"the_nether": "Nether",
+	"the_end":    "End",
+}
+
+type EntitiesPanel struct {
+	// dimension -> total loaded entities, missing for dimensions that failed
+	totals  map[string]int
+	counted time.Time
+
+	table  Table
+	loaded bool
+}
+
+func NewEntitiesPanel() EntitiesPanel {
+	columns := []Column{{Title: "Type"}}
+	for _, d := range mc.Dimensions {
+		columns = append(columns, Column{Title: dimensionTitles[d], Width: 10})
+	}
+	columns = append(columns, Column{Title: "Total", Width: 10})
+
+	return EntitiesPanel{
+		table: NewTable(columns...),
+	}
+}
+
+// countEntities returns the number of loaded entities matching the selector
+// arguments in a dimension. ok is false when the server rejected the test,
+// e.g. for a disabled dimension or an unknown entity type.
+func (m *Model) countEntities(dimension string, args string) (count int, ok bool) {
+	resp, err := m.rcon.Exec(mc.CountEntitiesCommand(dimension, args))
+	if err != nil {
+		m.err = err
+		return 0, false
+	}
+	_, count, err = mc.ParseTestResult(resp)
+	return count, err == nil
+}
+
+func (m *Model) FetchEntities() {
+	m.entities.totals = map[string]int{}
+	counts := map[string][]int{}
+
+	for di, dim := range mc.Dimensions {
+		total, ok := m.countEntities(dim, "")
+		if !ok {
+			continue
+		}
+		m.entities.totals[dim] = total
+		if total == 0 {
+			continue
+		}
+
+		for _, t := range mc.EntityTypes {
+			n, ok := m.countEntities(dim, "type=minecraft:"+t)
+			if !ok || n == 0 {
+				continue
+			}
+			if counts[t] == nil {
+				counts[t] = make([]int, len(mc.Dimensions))
+			}
+			counts[t][di] = n
+		}
+	}
+
+	type entry struct {
+		name   string
+		counts []int
+		total  int
+	}
+	var entries []entry
+	for t, c := range counts {
+		e := entry{name: t, counts: c}
+		for _, n := range c {
+			e.total += n
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].total != entries[j].total {
+			return entries[i].total > entries[j].total
+		}
+		return entries[i].name < entries[j].name
+	})
+
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		row := []string{e.name}
+		for di, dim := range mc.Dimensions {
+			if _, ok := m.entities.totals[dim]; !ok {
+				row = append(row, "-")
+				continue
+			}
+			row = append(row, strconv.Itoa(e.counts[di]))
+		}
+		rows[i] = append(row, strconv.Itoa(e.total))
+	}
+	m.entities.table.SetRows(rows)
+
+	m.entities.counted = time.Now()
+	m.entities.loaded = true
+}
+
+// askCleanup counts the entities matching the selector arguments in every
+// dimension and asks before killing them.
+func (m *Model) askCleanup(args string) {
+	if err := mc.ValidateCleanupSelector(args); err != nil {
+		m.err = err
+		return
+	}
+
+	var counts []string
+	for _, dim := range mc.Dimensions {
+		counts = append(counts, mc.CountEntitiesCommand(dim, args))
+	}
+	m.queue(m.execCmd(counts, func(m *Model, resps []string) {
+		preview := 0
+		for _, resp := range resps {
+			// rejected for disabled dimensions
+			if _, n, err := mc.ParseTestResult(resp); err == nil {
+				preview += n
+			}
+		}
+		if preview == 0 {
+			m.AppendLog(fmt.Sprintf("no loaded entities match @e[%s]", args))
+			return
+		}
+
+		m.AskConfirm(
+			fmt.Sprintf("Kill %d loaded entities matching @e[%s]?", preview, args),
+			[]string{fmt.Sprintf("kill @e[%s]", args)},
+			refreshPanel,
+		)
+	}, true))
+}
+
+func (m *Model) updateEntitiesPanel(msg tea.KeyMsg) bool {
+	if m.entities.table.Update(msg) {
+		return true
+	}
+
+	switch msg.String() {
+	case "k", "delete":
+		row, ok := m.entities.table.Selected()
+		if !ok {
+			return true
+		}
+		m.askCleanup("type=minecraft:" + row[0])
+
+	case "i":
+		m.askCleanup("type=minecraft:item")
+
+	case "o":
+		m.askCleanup("type=minecraft:experience_orb")
+
+	case "K":
+		m.AskPrompt("Kill entities matching @e[...] (type= required)", "type=minecraft:arrow", func(m *Model, args string) {
+			m.askCleanup(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(args), "["), "]"))
+		})
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewEntitiesPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Entities")
+
+	if !m.entities.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Counting...")
+	}
+
+	var totals []string
+	for _, dim := range mc.Dimensions {
+		if n, ok := m.entities.totals[dim]; ok {
+			totals = append(totals, fmt.Sprintf("%s: %d", dimensionTitles[dim], n))
+		}
+	}
+	totals = append(totals, "counted "+m.entities.counted.Format("15:04:05"))
+
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Width(width/3).Render(title),
+		lipgloss.NewStyle().
+			Width(width-width/3).
+			Align(lipgloss.Right).
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(strings.Join(totals, " | ")),
+	)
+
+	// the biggest contributors stand out
+	rows := m.entities.table.Rows()
+	top := map[string]string{}
+	for i := 0; i < len(rows) && i < 3; i++ {
+		color := m.colors.yellow
+		if i == 0 {
+			color = m.colors.red
+		}
+		top[rows[i][0]] = color
+	}
+
+	table := m.entities.table
+	table.rowColor = func(row []string) string {
+		return top[row[0]]
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		table.View(
+			width, height-3,
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[k] Kill type | [i] Kill items | [o] Kill XP orbs | [K] Kill selector | [r] Recount"),
+	)
+}
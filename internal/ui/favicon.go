@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	FaviconAuto   = "auto"
+	FaviconBlocks = "blocks"
+	FaviconKitty  = "kitty"
+	FaviconOff    = "off"
+)
+
+// favicon size in terminal cells, every cell holds two pixels vertically
+const (
+	faviconCols = 8
+	faviconRows = 4
+
+	kittyImageID = 42
+)
+
+type Favicon struct {
+	mode string
+
+	source   string // base64 data from the status response
+	rendered string
+	png      []byte
+
+	// kitty needs the image uploaded once before placeholders can show it
+	pending bool
+}
+
+// SetFaviconMode selects how the server icon is drawn: auto, blocks, kitty
+// or off.
+func (m *Model) SetFaviconMode(mode string) {
+	if mode == FaviconAuto {
+		mode = detectGraphics()
+	}
+	m.favicon.mode = mode
+}
+
+// detectGraphics checks for terminals known to implement the kitty graphics
+// protocol with unicode placeholders. Sixel images cannot be positioned
+// inside a lipgloss layout, so every other terminal gets half blocks.
+func detectGraphics() string {
+	if os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("TERM") == "xterm-kitty" {
+		return FaviconKitty
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "ghostty", "WezTerm":
+		return FaviconKitty
+	}
+	return FaviconBlocks
+}
+
+func (m *Model) UpdateFavicon(status mc.StatusResponse) {
+	if m.favicon.mode == FaviconOff || status.Favicon == m.favicon.source {
+		return
+	}
+	m.favicon.source = status.Favicon
+
+	img, raw, err := mc.DecodeFavicon(status.Favicon)
+	if err != nil {
+		m.favicon.rendered = ""
+		return
+	}
+
+	if m.favicon.mode == FaviconKitty {
+		m.favicon.png = raw
+		m.favicon.rendered = kittyPlaceholder(kittyImageID, faviconCols, faviconRows)
+		m.favicon.pending = true
+		return
+	}
+	m.favicon.rendered = renderHalfBlocks(img, faviconCols, faviconRows*2)
+}
+
+// transmitFavicon uploads the favicon to a kitty compatible terminal, once
+// per icon. Println output is dropped in the alt screen, so it is written to
+// stdout directly, in one call: the terminal gets it between two frames,
+// which the renderer also writes in one call each.
+func (m *Model) transmitFavicon() tea.Cmd {
+	if !m.favicon.pending {
+		return nil
+	}
+	m.favicon.pending = false
+	seq := kittyTransmit(m.favicon.png, kittyImageID, faviconCols, faviconRows)
+
+	return func() tea.Msg {
+		os.Stdout.WriteString(seq)
+		return nil
+	}
+}
+
+func kittyTransmit(png []byte, id, cols, rows int) string {
+	data := base64.StdEncoding.EncodeToString(png)
+
+	var b strings.Builder
+	for i := 0; i < len(data); i += 4096 {
+		end := min(i+4096, len(data))
+		more := 0
+		if end < len(data) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,q=2,f=100,U=1,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", id, cols, rows, more, data[i:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, data[i:end])
+		}
+	}
+	return b.String()
+}
+
+// row/column diacritics from the kitty unicode placeholder spec
+var kittyDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F,
+	0x0346, 0x034A, 0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357,
+}
+
+func kittyPlaceholder(id, cols, rows int) string {
+	var lines []string
+	for r := 0; r < rows; r++ {
+		var b strings.Builder
+		// the image id is passed in the foreground color
+		fmt.Fprintf(&b, "\x1b[38;5;%dm", id)
+		for c := 0; c < cols; c++ {
+			b.WriteRune(0x10EEEE)
+			b.WriteRune(kittyDiacritics[r])
+			b.WriteRune(kittyDiacritics[c])
+		}
+		b.WriteString("\x1b[39m")
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderHalfBlocks draws img as w x h pixels using "▀", so the result is w
+// cells wide and h/2 cells tall.
+func renderHalfBlocks(img image.Image, w, h int) string {
+	b := img.Bounds()
+
+	sample := func(x, y int) (color.NRGBA, bool) {
+		x0 := b.Min.X + x*b.Dx()/w
+		x1 := b.Min.X + (x+1)*b.Dx()/w
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := b.Min.Y + (y+1)*b.Dy()/h
+
+		var r, g, bl, a, n uint32
+		for py := y0; py < max(y1, y0+1); py++ {
+			for px := x0; px < max(x1, x0+1); px++ {
+				c := color.NRGBAModel.Convert(img.At(px, py)).(color.NRGBA)
+				r += uint32(c.R)
+				g += uint32(c.G)
+				bl += uint32(c.B)
+				a += uint32(c.A)
+				n++
+			}
+		}
+		c := color.NRGBA{uint8(r / n), uint8(g / n), uint8(bl / n), uint8(a / n)}
+		return c, c.A >= 128
+	}
+
+	hex := func(c color.NRGBA) lipgloss.Color {
+		return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+	}
+
+	var lines []string
+	for y := 0; y+1 < h; y += 2 {
+		var line strings.Builder
+		for x := 0; x < w; x++ {
+			top, topOk := sample(x, y)
+			bottom, bottomOk := sample(x, y+1)
+
+			switch {
+			case topOk && bottomOk:
+				line.WriteString(lipgloss.NewStyle().Foreground(hex(top)).Background(hex(bottom)).Render("▀"))
+			case topOk:
+				line.WriteString(lipgloss.NewStyle().Foreground(hex(top)).Render("▀"))
+			case bottomOk:
+				line.WriteString(lipgloss.NewStyle().Foreground(hex(bottom)).Render("▄"))
+			default:
+				line.WriteString(" ")
+			}
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
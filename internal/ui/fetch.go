@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+	"sebpok/mc-rcon-tui/internal/rcon"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Keys of the background fetches besides the panel tabs.
+const (
+	fetchData   = "data"
+	fetchPlayer = "player"
+)
+
+// fetchState tracks the background fetch of the header data, the player
+// popup or a panel.
+type fetchState struct {
+	loading bool
+	updated time.Time
+	// the last fetch failed, the data shown is from an earlier one
+	failed bool
+	// a refresh was asked for while loading, the data may predate a change
+	again bool
+}
+
+// fetchMsg carries the result of a fetch that ran on a copy of the model.
+// apply moves the fetched state into the live model.
+type fetchMsg struct {
+	key    string
+	result *Model
+	run    func(m *Model)
+	apply  func(m *Model, r *Model)
+}
+
+// execMsg carries the responses of commands sent in the background.
+type execMsg struct {
+	cmds  []string
+	resps []string
+	err   error
+	after func(m *Model, resps []string)
+	// not written to the logs
+	quiet bool
+}
+
+// execQueue sends the commands of RunCmds one batch at a time, in the order
+// they were queued. Running each batch in its own tea.Cmd would let two
+// commands sent in quick succession reach the server in either order.
+type execQueue struct {
+	client *rcon.Client
+
+	mu   sync.Mutex
+	jobs []*execJob
+	wake chan struct{}
+}
+
+type execJob struct {
+	msg  execMsg
+	done chan execMsg
+}
+
+func newExecQueue(client *rcon.Client) *execQueue {
+	q := &execQueue{client: client, wake: make(chan struct{}, 1)}
+	go q.run()
+	return q
+}
+
+// push queues a batch without blocking the UI.
+func (q *execQueue) push(msg execMsg) *execJob {
+	j := &execJob{msg: msg, done: make(chan execMsg, 1)}
+	q.mu.Lock()
+	q.jobs = append(q.jobs, j)
+	q.mu.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return j
+}
+
+func (q *execQueue) run() {
+	for range q.wake {
+		for {
+			q.mu.Lock()
+			if len(q.jobs) == 0 {
+				q.mu.Unlock()
+				break
+			}
+			j := q.jobs[0]
+			q.jobs = q.jobs[1:]
+			q.mu.Unlock()
+
+			msg := j.msg
+			for _, c := range msg.cmds {
+				resp, err := q.client.Exec(c)
+				if err != nil {
+					msg.err = err
+					break
+				}
+				msg.resps = append(msg.resps, resp)
+			}
+			j.done <- msg
+		}
+	}
+}
+
+func (m *Model) fetchState(key string) *fetchState {
+	s, ok := m.fetches[key]
+	if !ok {
+		s = &fetchState{}
+		m.fetches[key] = s
+	}
+	return s
+}
+
+// fetch runs the Fetch* function on a copy of the model so slow RCON or
+// ping calls do not block the UI. Only one fetch per key runs at a time.
+func (m *Model) fetch(key string, run func(m *Model), apply func(m *Model, r *Model)) tea.Cmd {
+	s := m.fetchState(key)
+	if m.rcon == nil {
+		return nil
+	}
+	if s.loading {
+		s.again = true
+		return nil
+	}
+	s.loading = true
+
+	c := *m
+	c.err = nil
+	c.logs = nil
+	popup := *m.popup
+	c.popup = &popup
+	// maps the UI keeps changing while the fetch runs
+	c.ops.known = maps.Clone(m.ops.known)
+	c.bossbars.known = maps.Clone(m.bossbars.known)
+
+	return func() tea.Msg {
+		run(&c)
+		return fetchMsg{key: key, result: &c, run: run, apply: apply}
+	}
+}
+
+func (m *Model) handleFetch(msg fetchMsg) tea.Cmd {
+	s := m.fetchState(msg.key)
+	s.loading = false
+	s.failed = msg.result.err != nil
+	if s.failed {
+		m.err = msg.result.err
+	} else {
+		s.updated = time.Now()
+	}
+
+	msg.apply(m, msg.result)
+	m.appendLogLines(msg.result.logs...)
+
+	if s.again {
+		s.again = false
+		return m.fetch(msg.key, msg.run, msg.apply)
+	}
+	return nil
+}
+
+// queue schedules a command to be returned by the current Update.
+func (m *Model) queue(cmd tea.Cmd) {
+	if cmd != nil {
+		m.pending = append(m.pending, cmd)
+	}
+}
+
+// refreshPanel fetches the active panel again, e.g. after a change.
+func refreshPanel(m *Model, _ []string) {
+	m.queue(m.FetchPanelCmd())
+}
+
+// refreshData fetches the header and player list again.
+func refreshData(m *Model, _ []string) {
+	m.queue(m.FetchDataCmd())
+}
+
+// FetchDataCmd refreshes the server info and the online players.
+func (m *Model) FetchDataCmd() tea.Cmd {
+	return m.fetch(fetchData, (*Model).FetchData, func(m *Model, r *Model) {
+		m.setPlayerItems(r.players.Items())
+		m.status = r.status
+		m.favicon = r.favicon
+		m.pingMs = r.pingMs
+		m.version = r.version
+		m.slots = r.slots
+		m.motd = r.motd
+		m.software = r.software
+		m.serverVersion = r.serverVersion
+		m.perf = r.perf
+		m.time = r.time
+	})
+}
+
+// FetchPlayerDetailsCmd refreshes the popup of the selected player.
+func (m *Model) FetchPlayerDetailsCmd() tea.Cmd {
+	return m.fetch(fetchPlayer, (*Model).FetchPlayerDetails, func(m *Model, r *Model) {
+		// the popup was closed or another player selected meanwhile
+		if !m.popup.shown || r.popup.player.Nickname != m.selectedPlayerName() || r.offline.shown != m.offline.shown {
+			return
+		}
+		m.popup.player = r.popup.player
+		m.popup.shown = r.popup.shown
+	})
+}
+
+// FetchPanelCmd refreshes the active panel tab.
+func (m *Model) FetchPanelCmd() tea.Cmd {
+	tab := m.tabs[m.tabActiveIndex]
+	return m.fetch(tab, (*Model).FetchPanel, func(m *Model, r *Model) {
+		m.applyPanel(tab, r)
+	})
+}
+
+func (m Model) selectedPlayerName() string {
+	if item, ok := m.playerList().SelectedItem().(playerItem); ok {
+		return item.Name
+	}
+	return ""
+}
+
+// RunCmds sends commands in the background. When done they are logged with
+// their responses and after is called.
+func (m *Model) RunCmds(cmds []string, after func(m *Model, resps []string)) {
+	m.queue(m.execCmd(cmds, after, false))
+}
+
+// execCmd queues the commands right away, so they keep their order, and
+// returns a tea.Cmd waiting for their responses.
+func (m *Model) execCmd(cmds []string, after func(m *Model, resps []string), quiet bool) tea.Cmd {
+	if m.execQueue == nil {
+		return nil
+	}
+	j := m.execQueue.push(execMsg{cmds: cmds, after: after, quiet: quiet})
+	return func() tea.Msg {
+		return <-j.done
+	}
+}
+
+func (m *Model) handleExec(msg execMsg) {
+	for i, c := range msg.cmds {
+		if msg.quiet {
+			break
+		}
+		m.AppendLog("> " + c)
+		if i < len(msg.resps) {
+			m.AppendLog(msg.resps[i])
+			if hint := mc.Hint(mc.ClassifyResponse(msg.resps[i])); hint != "" {
+				m.AppendLog("hint: " + hint)
+			}
+		}
+	}
+	if msg.err != nil {
+		m.err = msg.err
+		return
+	}
+	if msg.after != nil {
+		msg.after(m, msg.resps)
+	}
+}
+
+// fetchStatus describes the state of the data behind a fetch key, "" when
+// it is fresh. Loading is only reported for the first fetch unless
+// withLoading is set, fetches running every second would flicker.
+func (m Model) fetchStatus(key string, withLoading bool) string {
+	s, ok := m.fetches[key]
+	if !ok {
+		return ""
+	}
+	switch {
+	case s.failed && !s.updated.IsZero():
+		return "stale since " + s.updated.Format("15:04:05")
+	case s.failed:
+		return "stale"
+	case s.loading && (withLoading || s.updated.IsZero()):
+		return "loading..."
+	}
+	return ""
+}
+
+// viewRefreshInfo shows the countdown to the next refresh of the header
+// data, or its status while it is loading or stale.
+func (m Model) viewRefreshInfo() string {
+	if status := m.viewFetchStatus(fetchData, true); status != "" {
+		return status
+	}
+	return fmt.Sprintf("Refresh in: %d", m.refreshIn)
+}
+
+// viewFetchStatus renders fetchStatus, the stale marker stands out.
+func (m Model) viewFetchStatus(key string, withLoading bool) string {
+	status := m.fetchStatus(key, withLoading)
+	if status == "" {
+		return ""
+	}
+	color := m.colors.textDimmed()
+	if s := m.fetches[key]; s.failed {
+		color = m.colors.yellow
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(status)
+}
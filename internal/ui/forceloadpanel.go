@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ForceloadPanel struct {
+	// dimension -> force loaded chunks
+	chunks map[string][]mc.ChunkPos
+	active int
+
+	table       Table
+	loaded      bool
+	unsupported bool
+}
+
+func NewForceloadPanel() ForceloadPanel {
+	return ForceloadPanel{
+		table: NewTable(
+			Column{Title: "Chunk"},
+			Column{Title: "Blocks"},
+		),
+	}
+}
+
+func (m *Model) FetchForceload() {
+	m.forceload.chunks = map[string][]mc.ChunkPos{}
+	for _, dim := range mc.Dimensions {
+		resp, err := m.rcon.Exec(fmt.Sprintf("execute in minecraft:%s run forceload query", dim))
+		if err != nil {
+			m.err = err
+			return
+		}
+		chunks, err := mc.ParseForceloadQuery(resp)
+		if errors.Is(err, mc.ErrUnknownCommand) && dim == mc.Dimensions[0] {
+			m.forceload.unsupported = true
+			return
+		}
+		if err != nil {
+			// dimension disabled on this server
+			continue
+		}
+		m.forceload.chunks[dim] = chunks
+	}
+
+	m.buildForceloadRows()
+	m.forceload.loaded = true
+	m.forceload.unsupported = false
+}
+
+func (m *Model) buildForceloadRows() {
+	chunks := m.forceload.chunks[mc.Dimensions[m.forceload.active]]
+	rows := make([][]string, len(chunks))
+	for i, c := range chunks {
+		rows[i] = []string{
+			fmt.Sprintf("%d, %d", c.X, c.Z),
+			fmt.Sprintf("%d, %d", c.BlockX(), c.BlockZ()),
+		}
+	}
+	m.forceload.table.SetRows(rows)
+}
+
+// parseBlockRange parses "<x> <z> [<x2> <z2>]" block coordinates.
+func parseBlockRange(value string) ([]string, error) {
+	parts := strings.Fields(value)
+	if len(parts) != 2 && len(parts) != 4 {
+		return nil, fmt.Errorf("expected <x> <z> [<x2> <z2>], got %q", value)
+	}
+	for _, p := range parts {
+		if _, err := strconv.Atoi(p); err != nil {
+			return nil, fmt.Errorf("invalid coordinate %q", p)
+		}
+	}
+	return parts, nil
+}
+
+func (m *Model) updateForceloadPanel(msg tea.KeyMsg) bool {
+	if m.forceload.unsupported {
+		return false
+	}
+	if m.forceload.table.Update(msg) {
+		return true
+	}
+
+	dim := mc.Dimensions[m.forceload.active]
+	in := "execute in minecraft:" + dim + " run "
+
+	switch msg.String() {
+	case "[", "]":
+		n := len(mc.Dimensions)
+		if msg.String() == "]" {
+			m.forceload.active = (m.forceload.active + 1) % n
+		} else {
+			m.forceload.active = (m.forceload.active - 1 + n) % n
+		}
+		m.buildForceloadRows()
+
+	case "a", "d":
+		op, label := "add", "Force load in "+dimensionTitles[dim]
+		if msg.String() == "d" {
+			op, label = "remove", "Stop force loading in "+dimensionTitles[dim]
+		}
+		m.AskPrompt(label+": <x> <z> [<x2> <z2>] (block coordinates)", "0 0 31 31", func(m *Model, value string) {
+			parts, err := parseBlockRange(value)
+			if err != nil {
+				m.err = err
+				return
+			}
+			cmd := in + "forceload " + op + " " + strings.Join(parts, " ")
+			m.AskConfirm(fmt.Sprintf("Run %q?", cmd), []string{cmd}, refreshPanel)
+		})
+
+	case "x", "delete":
+		i := m.forceload.table.SelectedIndex()
+		if i < 0 {
+			return true
+		}
+		c := m.forceload.chunks[dim][i]
+		m.AskConfirm(
+			fmt.Sprintf("Stop force loading chunk %d, %d in %s?", c.X, c.Z, dimensionTitles[dim]),
+			[]string{fmt.Sprintf("%sforceload remove %d %d", in, c.BlockX(), c.BlockZ())},
+			refreshPanel,
+		)
+
+	case "X":
+		n := len(m.forceload.chunks[dim])
+		if n == 0 {
+			return true
+		}
+		m.AskConfirm(
+			fmt.Sprintf("Stop force loading all %d chunks in %s?", n, dimensionTitles[dim]),
+			[]string{in + "forceload remove all"},
+			refreshPanel,
+		)
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+// chunkGrid draws the force loaded chunks of the active dimension. Every
+// cell is two characters wide to keep chunks square, when the chunks do not
+// fit one cell covers several chunks.
+func (m Model) chunkGrid(width, height int) string {
+	dim := mc.Dimensions[m.forceload.active]
+	chunks := m.forceload.chunks[dim]
+	if len(chunks) == 0 {
+		return ""
+	}
+
+	minX, maxX, minZ, maxZ := chunks[0].X, chunks[0].X, chunks[0].Z, chunks[0].Z
+	for _, c := range chunks {
+		minX, maxX = min(minX, c.X), max(maxX, c.X)
+		minZ, maxZ = min(minZ, c.Z), max(maxZ, c.Z)
+	}
+
+	cols, rows := width/2, height-1
+	if cols < 1 || rows < 1 {
+		return ""
+	}
+	scale := 1
+	for (maxX-minX)/scale+1 > cols || (maxZ-minZ)/scale+1 > rows {
+		scale++
+	}
+	cols = min(cols, (maxX-minX)/scale+1)
+	rows = min(rows, (maxZ-minZ)/scale+1)
+
+	grid := make([][]int, rows)
+	for r := range grid {
+		grid[r] = make([]int, cols)
+	}
+	for _, c := range chunks {
+		grid[(c.Z-minZ)/scale][(c.X-minX)/scale]++
+	}
+
+	selR, selC := -1, -1
+	if i := m.forceload.table.SelectedIndex(); i >= 0 && i < len(chunks) {
+		selR, selC = (chunks[i].Z-minZ)/scale, (chunks[i].X-minX)/scale
+	}
+
+	loaded := lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.yellow))
+	empty := lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.textDimmed()))
+	selected := lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.red))
+
+	lines := []string{empty.Render(fmt.Sprintf("x %d..%d, z %d..%d", minX, maxX, minZ, maxZ))}
+	if scale > 1 {
+		lines[0] += empty.Render(fmt.Sprintf(", 1 cell = %dx%d chunks", scale, scale))
+	}
+	for r := range grid {
+		var b strings.Builder
+		for c, n := range grid[r] {
+			switch {
+			case r == selR && c == selC:
+				b.WriteString(selected.Render("██"))
+			case n > 0:
+				b.WriteString(loaded.Render("██"))
+			default:
+				b.WriteString(empty.Render("··"))
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) viewForceloadPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Force loaded chunks")
+
+	if m.forceload.unsupported {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			title,
+			lipgloss.NewStyle().
+				Foreground(lipgloss.Color(m.colors.yellow)).
+				Render("This server has no /forceload command (requires 1.13.1+)."),
+		)
+	}
+	if !m.forceload.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	var dims []string
+	for i, d := range mc.Dimensions {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if i == m.forceload.active {
+			style = m.styles.playerLabelSelected.Padding(0, 1)
+		}
+		label := dimensionTitles[d]
+		if chunks, ok := m.forceload.chunks[d]; ok {
+			label += fmt.Sprintf(" (%d)", len(chunks))
+		}
+		dims = append(dims, style.Render(label))
+	}
+
+	tableWidth := min(30, width/2)
+	bodyHeight := height - 4
+
+	body := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		lipgloss.NewStyle().MarginRight(2).Render(m.forceload.table.View(
+			tableWidth, bodyHeight,
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		)),
+		m.chunkGrid(width-tableWidth-2, bodyHeight),
+	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		lipgloss.NewStyle().Width(width).MaxHeight(1).Render(strings.Join(dims, "")),
+		lipgloss.NewStyle().Height(bodyHeight).Render(body),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[ / ] Dimension | [a/d] Add/remove range | [x/X] Remove chunk/all | [r] Refresh"),
+	)
+}
@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type GameRulesPanel struct {
+	rules  []mc.GameRule
+	values map[string]string
+
+	table  Table
+	loaded bool
+}
+
+func NewGameRulesPanel() GameRulesPanel {
+	return GameRulesPanel{
+		table: NewTable(
+			Column{Title: "Rule"},
+			Column{Title: "Value", Width: 10},
+			Column{Title: "Default", Width: 10},
+		),
+		values: map[string]string{},
+	}
+}
+
+// gameRuleTree returns a command tree listing the game rules: the one of
+// the completion when built from commands.json, otherwise the report or
+// `help gamerule`.
+func (m *Model) gameRuleTree() (*mc.CommandTree, error) {
+	if t := m.completion.tree; t != nil && t.Complete {
+		return t, nil
+	}
+	if m.worldDir != "" {
+		if t, err := mc.ReadCommandsReport(m.serverDir()); err == nil {
+			return t, nil
+		}
+	}
+	resp, err := m.rcon.Exec("help gamerule")
+	if err != nil {
+		return nil, err
+	}
+	t := mc.NewCommandTree()
+	t.AddUsage(resp)
+	return t, nil
+}
+
+func (m *Model) FetchGameRules() {
+	m.gamerules.rules = nil
+	m.gamerules.values = map[string]string{}
+	m.gamerules.loaded = true
+
+	t, err := m.gameRuleTree()
+	if err != nil {
+		m.err = err
+		return
+	}
+	names := mc.GameRuleNames(t)
+	if len(names) == 0 {
+		m.err = errors.New("the server lists no game rules")
+	}
+
+	var failed error
+	for _, name := range names {
+		resp, err := m.rcon.Exec("gamerule " + name)
+		if err != nil {
+			m.err = err
+			return
+		}
+		_, value, err := mc.ParseGameRule(resp)
+		if err != nil {
+			failed = err
+			continue
+		}
+		m.gamerules.rules = append(m.gamerules.rules, mc.NewGameRule(name, value))
+		m.gamerules.values[name] = value
+	}
+	if len(m.gamerules.rules) == 0 && failed != nil {
+		m.err = failed
+	}
+
+	m.buildGameRuleRows()
+}
+
+func (m *Model) buildGameRuleRows() {
+	rows := make([][]string, len(m.gamerules.rules))
+	for i, r := range m.gamerules.rules {
+		def := r.Default
+		if def == "" {
+			def = "?"
+		}
+		rows[i] = []string{r.Name, m.gamerules.values[r.Name], def}
+	}
+	m.gamerules.table.SetRows(rows)
+}
+
+func (m *Model) setGameRule(name string, value string) {
+	m.RunCmds([]string{fmt.Sprintf("gamerule %s %s", name, value)}, func(m *Model, resps []string) {
+		if _, v, err := mc.ParseGameRule(resps[0]); err == nil {
+			m.gamerules.values[name] = v
+		}
+		m.buildGameRuleRows()
+	})
+}
+
+func (m *Model) updateGameRulesPanel(msg tea.KeyMsg) bool {
+	if m.gamerules.table.Update(msg) {
+		return true
+	}
+
+	i := m.gamerules.table.SelectedIndex()
+	var rule mc.GameRule
+	if i >= 0 {
+		rule = m.gamerules.rules[i]
+	}
+
+	switch msg.String() {
+	case "enter", " ":
+		if i < 0 {
+			return true
+		}
+		if rule.Bool {
+			value := "true"
+			if m.gamerules.values[rule.Name] == "true" {
+				value = "false"
+			}
+			m.setGameRule(rule.Name, value)
+			return true
+		}
+		fallthrough
+
+	case "e":
+		if i < 0 {
+			return true
+		}
+		m.AskPrompt("Set "+rule.Name, m.gamerules.values[rule.Name], func(m *Model, value string) {
+			if !rule.Bool {
+				if _, err := strconv.Atoi(value); err != nil {
+					m.err = fmt.Errorf("%s expects an integer, got %q", rule.Name, value)
+					return
+				}
+			}
+			m.setGameRule(rule.Name, value)
+		})
+
+	case "d":
+		if i < 0 || m.gamerules.values[rule.Name] == rule.Default {
+			return true
+		}
+		if rule.Default == "" {
+			m.err = fmt.Errorf("%s has no known default", rule.Name)
+			return true
+		}
+		m.setGameRule(rule.Name, rule.Default)
+
+	case "x":
+		m.AskPrompt("Export game rule profile to", "gamerules.json", func(m *Model, path string) {
+			if err := mc.SaveGameRuleProfile(path, m.gamerules.values); err != nil {
+				m.err = err
+				return
+			}
+			m.AppendLog(fmt.Sprintf("exported %d game rules to %s", len(m.gamerules.values), path))
+		})
+
+	case "i":
+		m.AskPrompt("Import game rule profile from", "gamerules.json", func(m *Model, path string) {
+			profile, err := mc.LoadGameRuleProfile(path)
+			if err != nil {
+				m.err = err
+				return
+			}
+
+			var cmds []string
+			for name, value := range profile {
+				if current, ok := m.gamerules.values[name]; ok && current != value {
+					cmds = append(cmds, fmt.Sprintf("gamerule %s %s", name, value))
+				}
+			}
+			sort.Strings(cmds)
+			if len(cmds) == 0 {
+				m.AppendLog("profile matches the current game rules")
+				return
+			}
+			m.AskConfirm(fmt.Sprintf("Apply %d game rule changes from %s?", len(cmds), path), cmds, func(m *Model, _ []string) {
+				refreshPanel(m, nil)
+			})
+		})
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewGameRulesPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Game rules")
+
+	if !m.gamerules.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	changed, unknown := 0, 0
+	for _, r := range m.gamerules.rules {
+		switch {
+		case r.Default == "":
+			unknown++
+		case m.gamerules.values[r.Name] != r.Default:
+			changed++
+		}
+	}
+	info := fmt.Sprintf("%d changed from default", changed)
+	if unknown > 0 {
+		info += fmt.Sprintf(", %d without known default", unknown)
+	}
+
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Width(width/2).Render(title),
+		lipgloss.NewStyle().
+			Width(width-width/2).
+			Align(lipgloss.Right).
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(info),
+	)
+
+	table := m.gamerules.table
+	table.rowColor = func(row []string) string {
+		if row[2] != "?" && row[1] != row[2] {
+			return m.colors.yellow
+		}
+		return ""
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		table.View(
+			width, height-3,
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[enter] Toggle/edit | [e] Edit | [d] Default | [x] Export | [i] Import | [r] Refresh"),
+	)
+}
@@ -1,6 +1,10 @@
 package ui
 
-import "strings"
+import (
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+)
 
 func AsciiBar(percent float64, width int, fillChar string, emptyChar string) string {
 	if width <= 0 {
@@ -20,3 +24,12 @@ func AsciiBar(percent float64, width int, fillChar string, emptyChar string) str
 		strings.Repeat(emptyChar, width-filled) +
 		"]"
 }
+
+// ErrorText renders err for the footer, followed by a hint for the typed
+// RCON errors from the mc package.
+func ErrorText(err error) string {
+	if hint := mc.Hint(err); hint != "" {
+		return err.Error() + " (" + hint + ")"
+	}
+	return err.Error()
+}
@@ -2,11 +2,15 @@ package ui
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"sebpok/mc-rcon-tui/internal/actions"
+	"sebpok/mc-rcon-tui/internal/history"
 	"sebpok/mc-rcon-tui/internal/mc"
 	"sebpok/mc-rcon-tui/internal/rcon"
 
@@ -26,8 +30,8 @@ type Styles struct {
 
 	borderColor       lipgloss.Color
 	borderColorActive lipgloss.Color
-	textDark          lipgloss.Color
-	textDimmedDark    lipgloss.Color
+	textColor         lipgloss.Color
+	textDimmedColor   lipgloss.Color
 
 	separator lipgloss.Style
 
@@ -60,16 +64,16 @@ type Colors struct {
 	green  string
 	yellow string
 	red    string
-}
+	orange string
+	mint   string
 
-type PopupOptions struct {
-	label string
-	cmd   string
-	color string
+	// picks the Dark or Bright variant
+	dark bool
 }
 
 type PlayerSnapshot struct {
 	Nickname   string
+	UUID       string
 	Pos        mc.Vec3
 	Health     float64
 	Food       int
@@ -78,6 +82,11 @@ type PlayerSnapshot struct {
 	Dimension  string
 	Facing     string
 	HeldItem   mc.SelectedItem
+
+	// read from the playerdata file
+	Offline   bool
+	SavedAt   time.Time
+	Inventory []mc.InventoryItem
 }
 
 type Popup struct {
@@ -88,14 +97,16 @@ type Popup struct {
 
 	player PlayerSnapshot
 
-	options           []PopupOptions
+	actions []actions.Action
+	// menus opened from actions, innermost last
+	menus             []actions.Action
 	activeOptionIndex int
 }
 
-type playerItem string
-func (p playerItem) Title() string       { return string(p) }
+type playerItem mc.Player
+func (p playerItem) Title() string       { return p.Name }
 func (p playerItem) Description() string { return "" }
-func (p playerItem) FilterValue() string { return string(p) }
+func (p playerItem) FilterValue() string { return p.Name }
 
 type customDelegate struct{
 	playerInactiveStyle lipgloss.Style
@@ -126,8 +137,12 @@ type Model struct {
 	host string
 	port string
 
+	// optional, enables features that read the server files
+	worldDir string
+
 	players           list.Model
 	playerActiveIndex int
+	offline           OfflinePlayers
 
 	tabActiveIndex int
 	tabs           []string
@@ -137,14 +152,55 @@ type Model struct {
 	slots   string
 	motd    string
 
+	status        mc.StatusResponse
+	software      mc.Software
+	serverVersion string
+	perf     Performance
+	time     WorldTime
+	favicon  Favicon
+
 	err error
 
 	input    textinput.Model
 	popup    *Popup
+
+	// optional, commands typed in the cmds tab
+	history    *history.History
+	cmdHistory CmdHistory
+	completion Completion
+
+	// optional, reasons given for kicks and bans
+	reasons *history.History
+
 	viewport viewport.Model
 
+	confirm *Confirm
+	prompt  *Prompt
+	choice  *Choice
+
+	tick      TickPanel
+	plugins   PluginsPanel
+	whitelist WhitelistPanel
+	bans      BansPanel
+	ops        OpsPanel
+	scoreboard ScoreboardPanel
+	teams      TeamsPanel
+	gamerules  GameRulesPanel
+
+	worldborder WorldBorderPanel
+	entities    EntitiesPanel
+	datapacks   DatapacksPanel
+	bossbars    BossbarsPanel
+	forceload   ForceloadPanel
+
 	logs []string
 
+	// background fetches by key, and commands queued by the running Update
+	fetches map[string]*fetchState
+	pending []tea.Cmd
+	// commands sent by RunCmds, in order
+	execQueue *execQueue
+
 	hasProperResolution bool
 
 	refreshRate int
@@ -163,19 +219,20 @@ type Model struct {
 	ready bool
 }
 
-func DefaultStyles() Styles {
+// NewStyles builds the styles from the colors of a theme.
+func NewStyles(c *Colors) Styles {
 	s := Styles{
 		borderStyle: 	   lipgloss.RoundedBorder(),
 
-		borderColor:       lipgloss.Color("#666666"),
-		borderColorActive: lipgloss.Color("#da77f2"),
-		textDark:          lipgloss.Color("#eebefa"),
-		textDimmedDark:    lipgloss.Color("#555555"),
+		borderColor:       lipgloss.Color(c.border()),
+		borderColorActive: lipgloss.Color(c.borderActive()),
+		textColor:         lipgloss.Color(c.text()),
+		textDimmedColor:   lipgloss.Color(c.textDimmed()),
 	}
 
 	s.separator = lipgloss.NewStyle().
 		Height(1).
-		Foreground(lipgloss.Color(s.textDimmedDark))
+		Foreground(s.textDimmedColor)
 
 	s.inputField = lipgloss.NewStyle().
 		Border(s.borderStyle).
@@ -192,19 +249,19 @@ func DefaultStyles() Styles {
 	
 	s.title = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(s.textDark).
+		Foreground(s.textColor).
 		Align(lipgloss.Center)
 	
 	s.refreshInfo = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(s.textDimmedDark)).
+		Foreground(s.textDimmedColor).
 		Align(lipgloss.Right)
 
 	s.programVersion = lipgloss.NewStyle().
-		Foreground(s.textDimmedDark)
+		Foreground(s.textDimmedColor)
 
 	s.playersTitle = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(s.textDark).
+		Foreground(s.textColor).
 		Padding(0, 0).
 		Margin(0, 0, 0, 0)
 
@@ -213,38 +270,20 @@ func DefaultStyles() Styles {
 	
 	s.playerLabelSelected = lipgloss.NewStyle().
 		Bold(true).
-		Background(lipgloss.Color(s.textDimmedDark))
+		Foreground(lipgloss.Color(c.textActive())).
+		Background(s.textDimmedColor)
 
 	return s
 }
 
 func NewModel(client *rcon.Client, host string, refreshRateInSeconds int) Model {
-	c := &Colors{
-		textDark:         "#eebefa",
-		borderDark:       "#666666",
-		borderActiveDark: "#da77f2",
-		textDimmedDark:   "#555555",
-		yellow:           "#f59f00",
-		red:              "#f03e3e",
-		green:            "#37b24d",
-	}
+	c := DefaultColors(true)
 
 	p := &Popup{
 		width:  60,
 		height: 5,
 		shown:  false,
-		options: []PopupOptions{
-			{
-				label: "kick",
-				cmd:   "kick %s",
-				color: c.textDimmedDark,
-			},
-			{
-				label: "ban",
-				cmd:   "ban %s",
-				color: c.red,
-			},
-		},
+		actions:           actions.Default().Actions,
 		activeOptionIndex: 0,
 
 		player: PlayerSnapshot{},
@@ -257,23 +296,55 @@ func NewModel(client *rcon.Client, host string, refreshRateInSeconds int) Model
 	ti.CharLimit = 200
 	ti.Width = 40
 
+	var queue *execQueue
+	if client != nil {
+		queue = newExecQueue(client)
+	}
+
 	return Model{
 		rcon:              client,
+		execQueue:         queue,
 		colors:            c,
 		refreshRate:       refreshRateInSeconds,
 		refreshIn:         refreshRateInSeconds,
 		host:              host,
 		input:             ti,
+		cmdHistory:        CmdHistory{index: -1, match: -1},
 		playerActiveIndex: 0,
-		styles:            DefaultStyles(),
+		styles:            NewStyles(c),
 
-		tabs:           []string{"players", "cmds"},
+		tabs:           []string{"players", "cmds", "tick", "time", "plugins", "whitelist", "bans", "ops", "scoreboard", "teams", "gamerules", "worldborder", "entities", "datapacks", "bossbars", "forceload"},
 		tabActiveIndex: 0,
 
 		popup: p,
+
+		plugins:   NewPluginsPanel(),
+		whitelist: NewWhitelistPanel(),
+		bans:      NewBansPanel(),
+		ops:        NewOpsPanel(),
+		scoreboard: NewScoreboardPanel(),
+		teams:      NewTeamsPanel(),
+		gamerules:  NewGameRulesPanel(),
+		entities:   NewEntitiesPanel(),
+		datapacks:  NewDatapacksPanel(),
+		bossbars:   NewBossbarsPanel(),
+		forceload:  NewForceloadPanel(),
+		favicon:   Favicon{mode: FaviconBlocks},
+
+		fetches: map[string]*fetchState{},
 	}
 }
 
+// SetWorldDir sets the path of the world directory. The server directory is
+// assumed to be its parent, as in the default server layout.
+func (m *Model) SetWorldDir(dir string) {
+	m.worldDir = dir
+}
+
+func (m Model) serverDir() string {
+	return filepath.Dir(m.worldDir)
+}
+
 type initMsg struct{}
 
 func initCmd() tea.Cmd {
@@ -294,23 +365,15 @@ func (m Model) Init() tea.Cmd {
 
 func (m *Model) FetchData() {
 	// PLAYERS FETCH
-	resp, err := m.rcon.Exec("list")
-	if err != nil {
-		m.err = err
-	}
-
-	players := mc.ParsePlayers(resp)
-	playersForList := make([]list.Item, len(players))
-	for i, p := range players {
-		playersForList[i] = playerItem(p)
-	}
-	m.players.SetItems(playersForList)
+	m.FetchPlayerList()
 
 	// ------------ FETCH MC SPECIFIC REQUEST DATA ------------
 	data, ping, err := mc.Ping(m.host, "25565")
 	if err != nil {
 		m.err = err
 	}
+	m.status = data
+	m.UpdateFavicon(data)
 	m.pingMs = ping.Milliseconds()
 	m.version = data.Version.Name
 	m.slots = fmt.Sprintf("%d/%d", data.Players.Online, data.Players.Max)
@@ -321,15 +384,36 @@ func (m *Model) FetchData() {
 		m.err = err
 	}
 	m.motd = motd
+
+	if m.software == mc.SoftwareUnknown {
+		m.DetectSoftware(data)
+	}
+	m.FetchPerformance()
+	m.FetchTime()
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	cmds := append(m.pending, cmd)
+	m.pending = nil
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 
 	case initMsg:
 		return m, tickCmd()
 
+	case fetchMsg:
+		m.queue(m.handleFetch(msg))
+		return m, m.transmitFavicon()
+
+	case execMsg:
+		m.handleExec(msg)
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -357,8 +441,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			l.SetFilteringEnabled(false)
 			l.SetShowTitle(false)
 			m.players = l
+			m.offline.list = l
 
-			m.FetchData()
+			m.queue(m.FetchDataCmd())
 			m.ready = true
 		} else {
 			// logs
@@ -372,26 +457,47 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// players list
 			m.players.SetWidth(playersWidth)
 			m.players.SetHeight(playersHeight)
+			m.offline.list.SetWidth(playersWidth)
+			m.offline.list.SetHeight(playersHeight)
 		}
 
-		return m, nil
+		return m, m.transmitFavicon()
 
 	case tickMsg:
 		if m.popup.shown {
-			m.FetchPlayerDetails()
+			m.queue(m.FetchPlayerDetailsCmd())
+		}
+
+		m.TickBossbars()
+
+		if isLivePanel(m.tabs[m.tabActiveIndex]) && m.confirm == nil && m.prompt == nil && m.choice == nil {
+			m.queue(m.FetchPanelCmd())
 		}
 
 		if m.refreshIn <= 0 {
-			m.FetchData()
+			m.queue(m.FetchDataCmd())
 			m.refreshIn = m.refreshRate
 		} else {
 			m.refreshIn--
 		}
-		return m, tickCmd()
+		return m, tea.Batch(tickCmd(), m.transmitFavicon())
 
 	case tea.KeyMsg:
+		if handled, cmd := m.updateDialogs(msg); handled {
+			return m, cmd
+		}
+
+		if !m.popup.shown && isPanelTab(m.tabs[m.tabActiveIndex]) && m.updatePanel(msg) {
+			return m, nil
+		}
+
+		if m.input.Focused() && (m.updateCompletion(msg) || m.updateHistory(msg)) {
+			return m, nil
+		}
+
 		if m.input.Focused() {
 			m.input, cmd = m.input.Update(msg)
+			m.updateSuggestions()
 		}
 
 		switch msg.String() {
@@ -405,8 +511,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				if m.tabs[m.tabActiveIndex] == "cmds" {
 					m.input.Focus()
+					m.queue(m.LoadCommandsCmd())
 				} else {
 					m.input.Blur()
+					m.completion.suggestions = nil
+				}
+
+				if isPanelTab(m.tabs[m.tabActiveIndex]) {
+					m.queue(m.FetchPanelCmd())
 				}
 			}
 
@@ -414,14 +526,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.popup.shown {
 				m.popup.activeOptionIndex--
 				if m.popup.activeOptionIndex < 0 {
-					m.popup.activeOptionIndex = len(m.popup.options) - 1
+					m.popup.activeOptionIndex = len(m.popup.options()) - 1
 				}
 			}
 
 		case "right", "l":
 			if m.popup.shown {
 				m.popup.activeOptionIndex++
-				if m.popup.activeOptionIndex >= len(m.popup.options) {
+				if m.popup.activeOptionIndex >= len(m.popup.options()) {
 					m.popup.activeOptionIndex = 0
 				}
 			}
@@ -431,47 +543,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "cmds":
 				if m.input.Value() != "" {
 					currentCmd := m.input.Value()
-					// dodaj do logów
-					m.AppendLog("> " + m.input.Value())
 					m.input.SetValue("")
+					m.completion.suggestions = nil
+					m.recordCommand(currentCmd)
 
-					resp, err := m.rcon.Exec(currentCmd)
-					if err != nil {
-						m.err = err
-					}
-					m.AppendLog(resp)
+					m.RunCmds([]string{currentCmd}, nil)
 				}
 
 			case "players":
-				if !m.popup.shown && len(m.players.Items()) > 0 {
+				if !m.popup.shown && len(m.playerList().Items()) > 0 {
 					m.popup.shown = true
-					m.FetchPlayerDetails()
+					if len(m.popup.menus) > 0 {
+						m.popup.menus = nil
+						m.popup.activeOptionIndex = 0
+					}
+					// don't show the previous player until the details arrive
+					m.popup.player = PlayerSnapshot{Nickname: m.selectedPlayerName()}
+					m.fetchState(fetchPlayer).updated = time.Time{}
+					m.queue(m.FetchPlayerDetailsCmd())
 				} else {
-					if len(m.players.Items()) > 0 {
-						player := m.players.SelectedItem()
-						option := m.popup.options[m.popup.activeOptionIndex]
-						cmd := fmt.Sprintf(option.cmd, player)
-						m.AppendLog("> " + cmd)
-						m.popup.shown = false
-
-						resp, err := m.rcon.Exec(cmd)
-						if err != nil {
-							m.err = err
-						}
-						m.AppendLog(resp)
-						m.FetchData()
+					if len(m.playerList().Items()) > 0 {
+						m.selectPopupOption()
 					}
 				}
 			}
 			return m, nil
 
+		case "o":
+			if m.tabs[m.tabActiveIndex] == "players" && !m.popup.shown && m.worldDir != "" {
+				m.offline.shown = !m.offline.shown
+				if m.offline.shown {
+					m.LoadOfflinePlayers()
+				}
+			}
+
 		case "ctrl+l":
 			m.logs = nil
 			m.viewport.SetContent("")
 
 		case "ctrl+c", "esc":
 			if m.popup.shown {
-				m.popup.shown = false
+				if !m.popup.closeMenu() {
+					m.popup.shown = false
+				}
 				return m, nil
 			}
 			return m, tea.Quit
@@ -479,7 +593,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	if m.tabs[m.tabActiveIndex] == "players" {
-		m.players, cmd = m.players.Update(msg)
+		l := m.playerList()
+		*l, cmd = l.Update(msg)
 	}
 	return m, cmd
 }
@@ -509,17 +624,17 @@ func (m Model) View() string {
 		lipgloss.Center,
 		programVersionBox.Render("v1.0"),
 		titleBox.Render("Minecraft RCON Console"),
-		refreshBox.Render(fmt.Sprintf("Refresh in: %d", m.refreshIn)),
+		refreshBox.Render(m.viewRefreshInfo()),
 	)
 
 	// ------------- footer ------------------
 	var footerBox lipgloss.Style
 	if m.err != nil {
 		footerBox = lipgloss.NewStyle().
-			SetString(m.err.Error()).Foreground(lipgloss.Color(m.colors.red))
+			SetString(ErrorText(m.err)).Foreground(lipgloss.Color(m.colors.red))
 	} else {
 		footerBox = lipgloss.NewStyle().
-			SetString("[esc] Quit | [tab] Switch tabs | [ctrl+l] Clear logs").Foreground(lipgloss.Color(m.colors.textDimmedDark))
+			SetString("[esc] Quit | [tab] Switch tabs (" + m.tabs[m.tabActiveIndex] + ") | [ctrl+l] Clear logs" + m.offlineHint() + m.historyHint()).Foreground(lipgloss.Color(m.colors.textDimmed()))
 	}
 
 	// ------------- main content ------------------
@@ -540,7 +655,7 @@ func (m Model) View() string {
 	versionInfoBoxContent := lipgloss.JoinHorizontal(
 		lipgloss.Left,
 		infoItemLabel.Render("Ver:"),
-		infoItemValue.Render(m.version),
+		infoItemValue.Render(m.softwareLabel()),
 	)
 
 	slotsInfoBoxContent := lipgloss.JoinHorizontal(
@@ -564,23 +679,42 @@ func (m Model) View() string {
 	motdInfoBoxContent := lipgloss.NewStyle().
 		Width(m.leftColumnWidth - 2).
 		Align(lipgloss.Left).
-		Foreground(lipgloss.Color(m.colors.textDimmedDark))
+		Foreground(lipgloss.Color(m.colors.textDimmed()))
 
-	infoBoxContent := lipgloss.JoinVertical(
-		lipgloss.Top,
+	infoRows := []string{
 		versionInfoBoxContent,
 
 		m.styles.separator.Render(strings.Repeat("-", m.leftColumnWidth-2)),
 
 		slotsInfoBoxContent,
 		pingInfoBoxContent,
-		motdInfoBoxContent.Render("MOTD: "+m.motd),
-	)
+		m.renderPerformance(infoItemLabel, infoItemValue),
+		m.renderTime(infoItemLabel, infoItemValue),
+	}
+
+	// header with the server icon next to the address and MOTD
+	if m.favicon.rendered != "" {
+		headerTextWidth := m.leftColumnWidth - 2 - faviconCols - 1
+		infoHeaderContent := lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			lipgloss.NewStyle().MarginRight(1).Render(m.favicon.rendered),
+			lipgloss.JoinVertical(
+				lipgloss.Top,
+				lipgloss.NewStyle().Bold(true).Foreground(m.styles.textColor).Width(headerTextWidth).Render(m.host),
+				motdInfoBoxContent.Width(headerTextWidth).MaxHeight(faviconRows-1).Render(m.motd),
+			),
+		)
+		infoRows = append([]string{infoHeaderContent}, infoRows...)
+	} else {
+		infoRows = append(infoRows, motdInfoBoxContent.Render("MOTD: "+m.motd))
+	}
+
+	infoBoxContent := lipgloss.JoinVertical(lipgloss.Top, infoRows...)
 
 	// players
 	playerPopup := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(m.colors.borderDark)).
+		BorderForeground(lipgloss.Color(m.colors.border())).
 		Padding(1, 2).
 		Width(m.popup.width).
 		Height(m.popup.height)
@@ -600,7 +734,7 @@ func (m Model) View() string {
 	leftColumn := lipgloss.JoinVertical(
 		lipgloss.Top,
 		infoBox.Render(infoBoxContent),
-		playerBox.Render("Online:\n" + m.players.View()),
+		m.viewPlayerBox(playerBox),
 	)
 
 	// ---------- input ------------
@@ -613,18 +747,32 @@ func (m Model) View() string {
 		inputStyle = m.styles.inputField.Width(m.rightColumnWidth - 2)
 	}
 	inputView := inputStyle.Render(m.input.View())
+	if m.cmdHistory.searching {
+		inputView = inputStyle.Render(m.viewHistorySearch())
+	}
 
 	// ---------- logs  ------------
+	rightBox := m.styles.box.
+		Width(m.rightColumnWidth - 2).
+		Height(infoBoxHeight - 6)
+
+	var rightContent string
+	if isPanelTab(m.tabs[m.tabActiveIndex]) {
+		rightBox = rightBox.
+			Height(m.viewport.Height).
+			BorderForeground(m.styles.borderColorActive)
+		rightContent = m.viewPanel(m.viewport.Width, m.viewport.Height)
+	} else {
+		rightContent = m.viewport.View()
+		if m.tabs[m.tabActiveIndex] == "cmds" {
+			rightContent = m.viewSuggestions(rightContent, m.viewport.Width)
+		}
+	}
 
 	// ---------- right column assembly  ------------
 	rightColumn := lipgloss.JoinVertical(
 		lipgloss.Top,
-		m.styles.box.
-			Width(m.rightColumnWidth - 2).
-			Height(infoBoxHeight - 6).
-			Render(
-				m.viewport.View(),
-			),
+		rightBox.Render(rightContent),
 		inputView,
 	)
 
@@ -635,6 +783,14 @@ func (m Model) View() string {
 		rightColumn,
 	)
 
+	if dialog := m.viewDialog(); dialog != "" {
+		return lipgloss.Place(
+			m.width, m.height,
+			lipgloss.Center, lipgloss.Center,
+			dialog,
+		)
+	}
+
 	// ---------- render body if popup is hidden ------------
 	if !m.popup.shown {
 		return lipgloss.JoinVertical(
@@ -648,14 +804,25 @@ func (m Model) View() string {
 	playerPopupNickname := lipgloss.NewStyle().
 		Bold(true).
 		Width(m.popup.width - 4).
-		Foreground(lipgloss.Color(m.colors.textDark)).
+		Foreground(lipgloss.Color(m.colors.text())).
 		Align(lipgloss.Center).
-		Render(m.popup.player.Nickname)
+		Render(m.popupTitle())
+
+	// the name can change, the UUID identifies the player
+	uuid := m.popup.player.UUID
+	if uuid == "" {
+		uuid = "UUID unknown"
+	}
+	playerPopupUUID := lipgloss.NewStyle().
+		Width(m.popup.width - 4).
+		Foreground(lipgloss.Color(m.colors.textDimmed())).
+		Align(lipgloss.Center).
+		Render(uuid)
 
 	playerPopupSeparator := lipgloss.NewStyle().
 		Width(m.popup.width - 4).
 		Height(1).
-		Foreground(lipgloss.Color(m.colors.textDimmedDark)).
+		Foreground(lipgloss.Color(m.colors.textDimmed())).
 		Render(strings.Repeat("-", m.popup.width-4))
 
 	playerPopupStatLabel := lipgloss.NewStyle().
@@ -670,7 +837,7 @@ func (m Model) View() string {
 		lipgloss.Left,
 		playerPopupStatLabel.Render("Position XYZ:"),
 		playerPopupStatValue.
-			Foreground(lipgloss.Color(m.colors.textDark)).
+			Foreground(lipgloss.Color(m.colors.text())).
 			Render(
 				fmt.Sprintf(
 					"[%.1f, %.1f, %.1f]",
@@ -693,7 +860,7 @@ func (m Model) View() string {
 		lipgloss.Left,
 		playerPopupStatLabel.Render("Food:"),
 		playerPopupStatValue.
-			Foreground(lipgloss.Color("173")).
+			Foreground(lipgloss.Color(m.colors.orange)).
 			Render(AsciiBar(float64(m.popup.player.Food)/20, 20, "█", "░")),
 	)
 
@@ -701,7 +868,7 @@ func (m Model) View() string {
 		lipgloss.Left,
 		playerPopupStatLabel.Render("XP:"),
 		playerPopupStatValue.
-			Foreground(lipgloss.Color("114")).
+			Foreground(lipgloss.Color(m.colors.mint)).
 			Render(
 				fmt.Sprintf(
 					"%dlvl + (%.0f%%)",
@@ -723,39 +890,20 @@ func (m Model) View() string {
 		playerPopupStatValue.Render(m.popup.player.HeldItem.ID),
 	)
 
-	playerPopupStats := lipgloss.JoinVertical(
-		lipgloss.Top,
+	statsRows := []string{
 		playerPopupStatsPos,
 		playerPopupStatsHealth,
 		playerPopupStatsFood,
 		playerPopupStatsXP,
 		playerPopupStatsDimension,
 		playerPopupStatsSelectedItem,
-	)
-
-	playerPopupOption := lipgloss.NewStyle().
-		Bold(true).
-		Width((m.popup.width - 4) / len(m.popup.options)).
-		MarginTop(1).
-		Align(lipgloss.Center)
-
-	optionsLines := []string{}
-	for i, o := range m.popup.options {
-		if m.popup.activeOptionIndex == i {
-			optionsLines = append(
-				optionsLines, playerPopupOption.Background(lipgloss.Color(o.color)).Render(o.label),
-			)
-		} else {
-			optionsLines = append(
-				optionsLines, playerPopupOption.Render(o.label),
-			)
-		}
 	}
+	if m.popup.player.Offline {
+		statsRows = append(statsRows, m.viewOfflineStats(playerPopupStatLabel, playerPopupStatValue)...)
+	}
+	playerPopupStats := lipgloss.JoinVertical(lipgloss.Top, statsRows...)
 
-	playerPopupOptions := lipgloss.JoinHorizontal(
-		lipgloss.Center,
-		optionsLines...,
-	)
+	playerPopupOptions := m.viewPopupOptions()
 
 	return lipgloss.Place(
 		m.width, m.height,
@@ -764,6 +912,7 @@ func (m Model) View() string {
 			lipgloss.JoinVertical(
 				lipgloss.Top,
 				playerPopupNickname,
+				playerPopupUUID,
 				playerPopupSeparator,
 				playerPopupStats,
 				playerPopupOptions,
@@ -780,10 +929,16 @@ func (m *Model) AppendLog(log string) {
 	now := time.Now().Format("15:04:05")
 	log = mc.RemoveColorCodes(log)
 
-	m.logs = append(
-		m.logs,
-		fmt.Sprintf("[%s] %s", now, log),
-	)
+	m.appendLogLines(fmt.Sprintf("[%s] %s", now, log))
+}
+
+// appendLogLines adds lines already formatted by AppendLog, e.g. the ones
+// written by a background fetch.
+func (m *Model) appendLogLines(lines ...string) {
+	if len(lines) == 0 {
+		return
+	}
+	m.logs = append(m.logs, lines...)
 
 	content := strings.Join(m.logs, "\n")
 
@@ -793,20 +948,26 @@ func (m *Model) AppendLog(log string) {
 }
 
 func (m *Model) FetchPlayerDetails() {
-	selected := m.players.SelectedItem()
-	playerName := string(selected.(playerItem))
+	if m.offline.shown {
+		m.FetchOfflinePlayerDetails()
+		return
+	}
+
+	selected := m.players.SelectedItem().(playerItem)
+	playerName := selected.Name
 	m.popup.player.Nickname = playerName
+	m.popup.player.UUID = selected.UUID
+	m.popup.player.Offline = false
 
 	//check if player is still online
-	resp, err := m.rcon.Exec("list")
+	players, err := m.listPlayers()
 	if err != nil {
 		m.err = err
 		return
 	}
 	var isPlayerOnline bool
-	players := mc.ParsePlayers(resp)
 	for _, p := range players {
-		if p == playerName {
+		if p.ID() == mc.Player(selected).ID() {
 			isPlayerOnline = true
 			break
 		}
@@ -818,12 +979,17 @@ func (m *Model) FetchPlayerDetails() {
 	}
 
 	// position
-	resp, err = m.rcon.Exec(fmt.Sprintf("data get entity %s Pos", playerName))
+	resp, err := m.rcon.Exec(fmt.Sprintf("data get entity %s Pos", playerName))
 	if err != nil {
 		m.err = err
 		return
 	}
 	pos, err := mc.ParsePosition(resp)
+	if errors.Is(err, mc.ErrNoSuchPlayer) {
+		m.popup.shown = false
+		m.AppendLog(fmt.Sprintf("%s is no longer online", playerName))
+		return
+	}
 	if err != nil {
 		m.err = err
 		return
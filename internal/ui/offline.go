@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// OfflinePlayers lists the players from usercache.json that are not online.
+// It replaces the online list while shown, their details are read from the
+// world's playerdata files.
+type OfflinePlayers struct {
+	list  list.Model
+	shown bool
+}
+
+func (m *Model) LoadOfflinePlayers() {
+	users, err := mc.ReadUserCache(m.serverDir())
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	online := map[string]bool{}
+	for _, item := range m.players.Items() {
+		p := item.(playerItem)
+		if p.UUID != "" {
+			online[p.UUID] = true
+		}
+		online[p.Name] = true
+	}
+
+	var items []list.Item
+	for _, u := range users {
+		if online[u.UUID] || online[u.Name] {
+			continue
+		}
+		items = append(items, playerItem{Name: u.Name, UUID: u.UUID})
+	}
+	m.offline.list.SetItems(items)
+}
+
+// playerList returns the list shown in the players box.
+func (m *Model) playerList() *list.Model {
+	if m.offline.shown {
+		return &m.offline.list
+	}
+	return &m.players
+}
+
+func (m *Model) FetchOfflinePlayerDetails() {
+	selected := m.playerList().SelectedItem().(playerItem)
+
+	data, err := mc.ReadPlayerData(m.worldDir, selected.UUID)
+	if err != nil {
+		m.err = err
+		m.popup.shown = false
+		return
+	}
+
+	m.popup.player = PlayerSnapshot{
+		Nickname:   selected.Name,
+		UUID:       selected.UUID,
+		Pos:        data.Pos,
+		Health:     data.Health,
+		Food:       data.Food,
+		XPLevel:    data.XPLevel,
+		XPProgress: data.XPProgress,
+		Dimension:  data.Dimension,
+		HeldItem:   data.HeldItem,
+		Offline:    true,
+		SavedAt:    data.SavedAt,
+		Inventory:  data.Inventory,
+	}
+}
+
+// inventorySummary returns the items in the inventory, merged by id and
+// sorted by count.
+func inventorySummary(items []mc.InventoryItem, max int) []string {
+	counts := map[string]int{}
+	for _, it := range items {
+		counts[strings.TrimPrefix(it.ID, "minecraft:")] += it.Count
+	}
+
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	var lines []string
+	for i, id := range ids {
+		if i == max {
+			lines = append(lines, fmt.Sprintf("... %d more", len(ids)-max))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%dx %s", counts[id], id))
+	}
+	return lines
+}
+
+func (m Model) viewPlayerBox(box lipgloss.Style) string {
+	if m.offline.shown {
+		return box.Render("Offline:\n" + m.offline.list.View())
+	}
+	return box.Render("Online:\n" + m.players.View())
+}
+
+func (m Model) offlineHint() string {
+	if m.worldDir == "" || m.tabs[m.tabActiveIndex] != "players" {
+		return ""
+	}
+	if m.offline.shown {
+		return " | [o] Online players"
+	}
+	return " | [o] Offline players"
+}
+
+func (m Model) popupTitle() string {
+	if m.popup.player.Offline {
+		return m.popup.player.Nickname + " (offline)"
+	}
+	if status := m.fetchStatus(fetchPlayer, false); status != "" {
+		return m.popup.player.Nickname + " (" + status + ")"
+	}
+	return m.popup.player.Nickname
+}
+
+// viewOfflineStats renders the rows only available from the playerdata file.
+func (m Model) viewOfflineStats(label, value lipgloss.Style) []string {
+	p := m.popup.player
+
+	rows := []string{
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			label.Render("Saved:"),
+			value.Render(p.SavedAt.Format("2006-01-02 15:04")),
+		),
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			label.Render("Inventory:"),
+			value.Render(fmt.Sprintf("%d stacks", len(p.Inventory))),
+		),
+	}
+
+	dimmed := lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.textDimmed()))
+	for _, line := range inventorySummary(p.Inventory, 8) {
+		rows = append(rows, dimmed.Render("  "+line))
+	}
+	return rows
+}
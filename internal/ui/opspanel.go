@@ -0,0 +1,246 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type OpsPanel struct {
+	table Table
+	// the mc.Player.ID of each row
+	ids    []string
+	loaded bool
+
+	// without ops.json the list is built from op/deop responses, by
+	// mc.Player.ID so renamed players keep their state
+	fromFile bool
+	known    map[string]knownOp
+}
+
+type knownOp struct {
+	name string
+	isOp bool
+	// the probe could not tell, the player was left untouched
+	unknown bool
+}
+
+func NewOpsPanel() OpsPanel {
+	return OpsPanel{
+		table: NewTable(
+			Column{Title: "Player"},
+			Column{Title: "Level", Width: 7},
+			Column{Title: "Bypass limit", Width: 12},
+		),
+		known: map[string]knownOp{},
+	}
+}
+
+func (m *Model) FetchOps() {
+	type op struct {
+		row []string
+		id  string
+	}
+	var ops []op
+
+	m.ops.fromFile = false
+	if m.worldDir != "" {
+		fileOps, err := mc.ReadOps(m.serverDir())
+		if err != nil {
+			m.err = err
+		} else {
+			m.ops.fromFile = true
+			for _, o := range fileOps {
+				bypass := "no"
+				if o.BypassesPlayerLimit {
+					bypass = "yes"
+				}
+				id := mc.Player{Name: o.Name, UUID: o.UUID}.ID()
+				ops = append(ops, op{[]string{o.Name, fmt.Sprintf("%d", o.Level), bypass}, id})
+			}
+		}
+	}
+
+	if !m.ops.fromFile {
+		for id, k := range m.ops.known {
+			if !k.isOp && !k.unknown {
+				continue
+			}
+			level := "?"
+			if k.unknown {
+				level = "unknown"
+			}
+			name := k.name
+			// the current name of a renamed player
+			for _, item := range m.players.Items() {
+				if p := mc.Player(item.(playerItem)); p.ID() == id {
+					name = p.Name
+				}
+			}
+			ops = append(ops, op{[]string{name, level, "?"}, id})
+		}
+	}
+
+	sort.Slice(ops, func(i, j int) bool { return strings.ToLower(ops[i].row[0]) < strings.ToLower(ops[j].row[0]) })
+	rows := make([][]string, len(ops))
+	m.ops.ids = make([]string, len(ops))
+	for i, o := range ops {
+		rows[i], m.ops.ids[i] = o.row, o.id
+	}
+	m.ops.table.SetRows(rows)
+	m.ops.loaded = true
+}
+
+// RecordOpResponse updates the known operators from an op/deop response.
+func (m *Model) RecordOpResponse(p mc.Player, resp string) {
+	if _, isOp, err := mc.ParseOpResponse(resp); err == nil {
+		m.ops.known[p.ID()] = knownOp{name: p.Name, isOp: isOp}
+	}
+}
+
+// onlinePlayer returns the online player with the name, or a player known
+// only by the name.
+func (m Model) onlinePlayer(name string) mc.Player {
+	for _, item := range m.players.Items() {
+		if p := mc.Player(item.(playerItem)); strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return mc.Player{Name: name}
+}
+
+// ProbeOps finds which online players are operators. Vanilla has no query
+// for it, so every player is opped and, if that made them an operator,
+// deopped again right away. Non-ops are operators for that moment.
+//
+// It runs on the copy of a fetch, the commands are logged with the fetch.
+func (m *Model) ProbeOps() {
+	for _, item := range m.players.Items() {
+		p := mc.Player(item.(playerItem))
+		name := p.Name
+
+		m.AppendLog("> op " + name)
+		resp, err := m.rcon.Exec("op " + name)
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.AppendLog(resp)
+
+		changed, _, parseErr := mc.ParseOpResponse(resp)
+		if parseErr != nil {
+			// deopping on a response in another wording could deop a
+			// real operator
+			m.ops.known[p.ID()] = knownOp{name: name, unknown: true}
+			m.err = parseErr
+			continue
+		}
+		if changed {
+			m.AppendLog("> deop " + name)
+			resp, err := m.rcon.Exec("deop " + name)
+			if err != nil {
+				m.err = fmt.Errorf("%s may still be an operator after probing: %w", name, err)
+				return
+			}
+			m.AppendLog(resp)
+		}
+		m.ops.known[p.ID()] = knownOp{name: name, isOp: !changed}
+	}
+	m.AppendLog(fmt.Sprintf("probed %d online players for operator status", len(m.players.Items())))
+}
+
+func (m *Model) updateOpsPanel(msg tea.KeyMsg) bool {
+	if m.ops.table.Update(msg) {
+		return true
+	}
+
+	switch msg.String() {
+	case "a":
+		m.AskPrompt("Give operator status to", "nickname", func(m *Model, name string) {
+			m.AskConfirm("Make "+name+" a server operator?", []string{"op " + name}, func(m *Model, resps []string) {
+				m.RecordOpResponse(m.onlinePlayer(name), resps[0])
+				refreshPanel(m, nil)
+			})
+		})
+
+	case "d", "delete":
+		i := m.ops.table.SelectedIndex()
+		if i < 0 {
+			return true
+		}
+		name, id := m.ops.table.Rows()[i][0], m.ops.ids[i]
+		m.AskConfirm("Remove operator status from "+name+"?", []string{"deop " + name}, func(m *Model, resps []string) {
+			if _, isOp, err := mc.ParseOpResponse(resps[0]); err == nil {
+				m.ops.known[id] = knownOp{name: name, isOp: isOp}
+			}
+			refreshPanel(m, nil)
+		})
+
+	case "P":
+		if m.ops.fromFile {
+			return true
+		}
+		m.AskConfirm("Probe online players? Each non-op is opped and deopped again immediately.", nil, func(m *Model, _ []string) {
+			probe := func(m *Model) {
+				m.ProbeOps()
+				m.FetchOps()
+			}
+			m.queue(m.fetch("ops probe", probe, func(m *Model, r *Model) {
+				m.ops.known = r.ops.known
+				m.applyPanel("ops", r)
+			}))
+		})
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewOpsPanel(width, height int) string {
+	source := "from ops.json"
+	if !m.ops.fromFile {
+		source = "probed, use --world for ops.json"
+	}
+
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		m.styles.playersTitle.Width(width/2).Render("Operators"),
+		lipgloss.NewStyle().
+			Width(width-width/2).
+			Align(lipgloss.Right).
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(source),
+	)
+
+	if !m.ops.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, header, "Loading...")
+	}
+
+	help := "[a] Op | [d] Deop | [r] Refresh"
+	if !m.ops.fromFile {
+		help += " | [P] Probe online players, ops each non-op for a moment"
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		m.ops.table.View(
+			width, height-3,
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(help),
+	)
+}
@@ -0,0 +1,230 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// isPanelTab reports whether the tab replaces the logs box with its own view.
+func isPanelTab(tab string) bool {
+	return tab != "players" && tab != "cmds"
+}
+
+// isLivePanel reports whether the panel is refreshed every second while it is
+// open. Other panels only fetch when opened or on demand.
+func isLivePanel(tab string) bool {
+	return tab == "tick" || tab == "time" || tab == "worldborder"
+}
+
+// FetchPanel refreshes the data shown by the active panel tab.
+func (m *Model) FetchPanel() {
+	switch m.tabs[m.tabActiveIndex] {
+	case "tick":
+		m.FetchTickState()
+	case "time":
+		m.FetchTime()
+	case "plugins":
+		m.FetchPlugins()
+	case "whitelist":
+		m.FetchWhitelist()
+	case "bans":
+		m.FetchBans()
+	case "ops":
+		m.FetchOps()
+	case "scoreboard":
+		m.FetchScoreboard()
+	case "teams":
+		m.FetchTeams()
+	case "gamerules":
+		m.FetchGameRules()
+	case "worldborder":
+		m.FetchWorldBorder()
+	case "entities":
+		m.FetchEntities()
+	case "datapacks":
+		m.FetchDatapacks()
+	case "bossbars":
+		m.FetchBossbars()
+	case "forceload":
+		m.FetchForceload()
+	}
+}
+
+// applyPanel moves the panel fetched in the background into the live model.
+// The table keeps its cursor and filter, which may have changed meanwhile.
+func (m *Model) applyPanel(tab string, r *Model) {
+	switch tab {
+	case "tick":
+		m.tick = r.tick
+	case "time":
+		m.time = r.time
+	case "plugins":
+		p := r.plugins
+		p.table = keepTable(m.plugins.table, p.table)
+		m.plugins = p
+	case "whitelist":
+		p := r.whitelist
+		p.table = keepTable(m.whitelist.table, p.table)
+		m.whitelist = p
+	case "bans":
+		p := r.bans
+		p.table = keepTable(m.bans.table, p.table)
+		m.bans = p
+	case "ops":
+		p := r.ops
+		p.table = keepTable(m.ops.table, p.table)
+		p.known = m.ops.known
+		m.ops = p
+	case "scoreboard":
+		p := r.scoreboard
+		p.table = keepTable(m.scoreboard.table, p.table)
+		if m.scoreboard.active < len(p.objectives) {
+			p.active = m.scoreboard.active
+		}
+		m.scoreboard = p
+		m.buildLeaderboard()
+	case "teams":
+		p := r.teams
+		p.table = keepTable(m.teams.table, p.table)
+		if m.teams.active < len(p.teams) {
+			p.active = m.teams.active
+		}
+		m.teams = p
+		m.buildTeamMembers()
+	case "gamerules":
+		p := r.gamerules
+		p.table = keepTable(m.gamerules.table, p.table)
+		m.gamerules = p
+	case "worldborder":
+		p := m.worldborder
+		if r.worldborder.fromFile && !p.fromFile {
+			p.border = r.worldborder.border
+			p.fromFile = true
+			p.centerKnown = true
+		}
+		p.border.Size = r.worldborder.border.Size
+		p.players = r.worldborder.players
+		p.playersFetched = r.worldborder.playersFetched
+		p.loaded = r.worldborder.loaded
+		m.worldborder = p
+	case "entities":
+		p := r.entities
+		p.table = keepTable(m.entities.table, p.table)
+		m.entities = p
+	case "datapacks":
+		p := r.datapacks
+		p.table = keepTable(m.datapacks.table, p.table)
+		p.feedback = m.datapacks.feedback
+		m.datapacks = p
+	case "bossbars":
+		// bars created or edited meanwhile are only in the live IDs
+		known := m.bossbars.known
+		for id, b := range r.bossbars.known {
+			if _, ok := known[id]; !ok {
+				known[id] = b
+			}
+		}
+		for _, id := range r.bossbars.removed {
+			delete(known, id)
+		}
+		p := r.bossbars
+		p.table = keepTable(m.bossbars.table, p.table)
+		p.known = known
+		p.countdowns = m.bossbars.countdowns
+		m.bossbars = p
+	case "forceload":
+		p := r.forceload
+		p.table = keepTable(m.forceload.table, p.table)
+		p.active = m.forceload.active
+		m.forceload = p
+		m.buildForceloadRows()
+	}
+}
+
+func keepTable(live, fetched Table) Table {
+	live.SetRows(fetched.rows)
+	return live
+}
+
+// updatePanel passes a key to the active panel tab. It returns false when
+// the panel does not use the key, so the global bindings can handle it.
+func (m *Model) updatePanel(msg tea.KeyMsg) bool {
+	switch m.tabs[m.tabActiveIndex] {
+	case "tick":
+		return m.updateTickPanel(msg)
+	case "time":
+		return m.updateTimePanel(msg)
+	case "plugins":
+		return m.updatePluginsPanel(msg)
+	case "whitelist":
+		return m.updateWhitelistPanel(msg)
+	case "bans":
+		return m.updateBansPanel(msg)
+	case "ops":
+		return m.updateOpsPanel(msg)
+	case "scoreboard":
+		return m.updateScoreboardPanel(msg)
+	case "teams":
+		return m.updateTeamsPanel(msg)
+	case "gamerules":
+		return m.updateGameRulesPanel(msg)
+	case "worldborder":
+		return m.updateWorldBorderPanel(msg)
+	case "entities":
+		return m.updateEntitiesPanel(msg)
+	case "datapacks":
+		return m.updateDatapacksPanel(msg)
+	case "bossbars":
+		return m.updateBossbarsPanel(msg)
+	case "forceload":
+		return m.updateForceloadPanel(msg)
+	}
+	return false
+}
+
+func (m Model) viewPanel(width, height int) string {
+	tab := m.tabs[m.tabActiveIndex]
+	status := m.viewFetchStatus(tab, !isLivePanel(tab))
+	if status == "" {
+		return m.viewPanelContent(tab, width, height)
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		lipgloss.NewStyle().Width(width).Align(lipgloss.Right).Render(status),
+		m.viewPanelContent(tab, width, height-1),
+	)
+}
+
+func (m Model) viewPanelContent(tab string, width, height int) string {
+	switch tab {
+	case "tick":
+		return m.viewTickPanel(width, height)
+	case "time":
+		return m.viewTimePanel(width, height)
+	case "plugins":
+		return m.viewPluginsPanel(width, height)
+	case "whitelist":
+		return m.viewWhitelistPanel(width, height)
+	case "bans":
+		return m.viewBansPanel(width, height)
+	case "ops":
+		return m.viewOpsPanel(width, height)
+	case "scoreboard":
+		return m.viewScoreboardPanel(width, height)
+	case "teams":
+		return m.viewTeamsPanel(width, height)
+	case "gamerules":
+		return m.viewGameRulesPanel(width, height)
+	case "worldborder":
+		return m.viewWorldBorderPanel(width, height)
+	case "entities":
+		return m.viewEntitiesPanel(width, height)
+	case "datapacks":
+		return m.viewDatapacksPanel(width, height)
+	case "bossbars":
+		return m.viewBossbarsPanel(width, height)
+	case "forceload":
+		return m.viewForceloadPanel(width, height)
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Performance struct {
+	TPS        float64
+	MSPT       float64
+	Dimensions []mc.DimensionTPS
+
+	// false until the first successful poll, or when the server has no
+	// command we can use
+	known       bool
+	unsupported bool
+}
+
+func (m *Model) DetectSoftware(status mc.StatusResponse) {
+	resp, err := m.rcon.Exec("version")
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.software = mc.DetectSoftware(resp, status)
+	m.serverVersion = mc.ParseVersion(resp)
+}
+
+func (m *Model) FetchPerformance() {
+	if m.perf.unsupported {
+		return
+	}
+
+	switch {
+	case m.software.IsBukkit():
+		resp, err := m.rcon.Exec("tps")
+		if err != nil {
+			m.err = err
+			return
+		}
+		t1, _, _, err := mc.ParseTPS(resp)
+		if errors.Is(err, mc.ErrUnknownCommand) {
+			m.perf.unsupported = true
+			return
+		}
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.perf.TPS = t1
+
+		if m.software == mc.SoftwareSpigot {
+			m.perf.MSPT = 0
+			break
+		}
+		resp, err = m.rcon.Exec("mspt")
+		if err != nil {
+			m.err = err
+			return
+		}
+		avg, _, _, err := mc.ParseMSPT(resp)
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.perf.MSPT = avg
+
+	case m.software.IsForge():
+		cmd := "forge tps"
+		if m.software == mc.SoftwareNeoForge {
+			cmd = "neoforge tps"
+		}
+		resp, err := m.rcon.Exec(cmd)
+		if err != nil {
+			m.err = err
+			return
+		}
+		overall, dims, err := mc.ParseForgeTPS(resp)
+		if errors.Is(err, mc.ErrUnknownCommand) {
+			m.perf.unsupported = true
+			return
+		}
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.perf.TPS = overall.TPS
+		m.perf.MSPT = overall.MSPT
+		m.perf.Dimensions = dims
+
+	default:
+		resp, err := m.rcon.Exec("tick query")
+		if err != nil {
+			m.err = err
+			return
+		}
+		q, err := mc.ParseTickQuery(resp)
+		if errors.Is(err, mc.ErrUnknownCommand) {
+			// pre 1.20.3 vanilla has no tick command
+			m.perf.unsupported = true
+			return
+		}
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.perf.TPS = q.TPS()
+		m.perf.MSPT = q.AvgMSPT
+	}
+
+	m.perf.known = true
+}
+
+func (m Model) tpsColor(tps float64) string {
+	switch {
+	case tps >= 18:
+		return m.colors.green
+	case tps >= 15:
+		return m.colors.yellow
+	default:
+		return m.colors.red
+	}
+}
+
+func (m Model) msptColor(mspt float64) string {
+	switch {
+	case mspt <= 40:
+		return m.colors.green
+	case mspt <= 50:
+		return m.colors.yellow
+	default:
+		return m.colors.red
+	}
+}
+
+// renderPerformance returns the TPS/MSPT rows of the info box.
+func (m Model) renderPerformance(label, value lipgloss.Style) string {
+	if !m.perf.known {
+		text := "-"
+		if m.perf.unsupported {
+			text = "n/a"
+		}
+		return lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			label.Render("TPS/MSPT:"),
+			value.Foreground(lipgloss.Color(m.colors.textDimmed())).Render(text),
+		)
+	}
+
+	tps := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.tpsColor(m.perf.TPS))).
+		Render(fmt.Sprintf("%.1f", m.perf.TPS))
+
+	mspt := "-"
+	if m.perf.MSPT > 0 {
+		mspt = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.msptColor(m.perf.MSPT))).
+			Render(fmt.Sprintf("%.1f ms", m.perf.MSPT))
+	}
+
+	rows := []string{
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			label.Render("TPS/MSPT:"),
+			value.Render(tps+" / "+mspt),
+		),
+	}
+
+	if len(m.perf.Dimensions) > 0 {
+		var dims []string
+		for _, d := range m.perf.Dimensions {
+			name := d.Name
+			if i := strings.LastIndex(name, ":"); i >= 0 {
+				name = name[i+1:]
+			}
+			dims = append(dims, lipgloss.NewStyle().
+				Foreground(lipgloss.Color(m.tpsColor(d.TPS))).
+				Render(fmt.Sprintf("%s %.1f", name, d.TPS)))
+		}
+		rows = append(rows, lipgloss.NewStyle().
+			Width(m.leftColumnWidth-2).
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(strings.Join(dims, " · ")))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top, rows...)
+}
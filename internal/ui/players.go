@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// listPlayers returns the online players. `list uuids` is preferred so
+// players keep their identity across name changes, servers without it fall
+// back to plain names.
+func (m *Model) listPlayers() ([]mc.Player, error) {
+	resp, err := m.rcon.Exec("list uuids")
+	if err != nil {
+		return nil, err
+	}
+	if mc.ClassifyResponse(resp) == nil {
+		return mc.ParsePlayersWithUUIDs(resp), nil
+	}
+
+	resp, err = m.rcon.Exec("list")
+	if err != nil {
+		return nil, err
+	}
+	var players []mc.Player
+	for _, name := range mc.ParsePlayers(resp) {
+		players = append(players, mc.Player{Name: name})
+	}
+	return players, nil
+}
+
+// FetchPlayerList refreshes the online list. The selection follows the
+// selected player rather than the row it was on.
+func (m *Model) FetchPlayerList() {
+	players, err := m.listPlayers()
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	var old []mc.Player
+	for _, item := range m.players.Items() {
+		old = append(old, mc.Player(item.(playerItem)))
+	}
+	renamed, oldNames := mc.DiffRenamed(old, players)
+	for i, p := range renamed {
+		m.AppendLog(fmt.Sprintf("%s is now known as %s", oldNames[i], p.Name))
+	}
+
+	items := make([]list.Item, len(players))
+	for i, p := range players {
+		items[i] = playerItem(p)
+	}
+	m.setPlayerItems(items)
+}
+
+func (m *Model) setPlayerItems(items []list.Item) {
+	var selected string
+	if item, ok := m.players.SelectedItem().(playerItem); ok {
+		selected = mc.Player(item).ID()
+	}
+
+	m.players.SetItems(items)
+
+	for i, item := range items {
+		if mc.Player(item.(playerItem)).ID() == selected {
+			m.players.Select(i)
+			break
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type PluginsPanel struct {
+	table   Table
+	plugins []mc.Plugin
+	loaded  bool
+	note    string
+}
+
+func NewPluginsPanel() PluginsPanel {
+	return PluginsPanel{
+		table: NewTable(
+			Column{Title: "Name"},
+			Column{Title: "Version", Width: 24},
+			Column{Title: "Type", Width: 8},
+			Column{Title: "Status", Width: 8},
+		),
+	}
+}
+
+func (m *Model) FetchPlugins() {
+	m.plugins.note = ""
+	m.plugins.plugins = nil
+
+	switch {
+	case m.software.IsBukkit():
+		resp, err := m.rcon.Exec("plugins")
+		if err != nil {
+			m.err = err
+			return
+		}
+		plugins, err := mc.ParsePlugins(resp)
+		if err != nil {
+			m.err = err
+			return
+		}
+
+		for i, p := range plugins {
+			resp, err := m.rcon.Exec("version " + p.Name)
+			if err != nil {
+				m.err = err
+				return
+			}
+			if v, err := mc.ParsePluginVersion(resp); err == nil {
+				plugins[i].Version = v
+			}
+		}
+		m.plugins.plugins = plugins
+
+	case m.software.IsForge():
+		mods, err := mc.ParseForgeMods(m.status)
+		if err != nil {
+			m.plugins.note = "The server does not advertise its mod list."
+		}
+		m.plugins.plugins = mods
+
+	case m.software == mc.SoftwareFabric:
+		m.plugins.note = "Fabric servers do not expose their mod list over RCON or status."
+
+	default:
+		m.plugins.note = "Vanilla servers have no plugins or mods."
+	}
+
+	rows := make([][]string, len(m.plugins.plugins))
+	for i, p := range m.plugins.plugins {
+		status := "enabled"
+		if !p.Enabled {
+			status = "disabled"
+		}
+		rows[i] = []string{p.Name, p.Version, p.Kind, status}
+	}
+	m.plugins.table.SetRows(rows)
+	m.plugins.loaded = true
+}
+
+func (m *Model) updatePluginsPanel(msg tea.KeyMsg) bool {
+	if m.plugins.table.Update(msg) {
+		return true
+	}
+
+	switch msg.String() {
+	case "r":
+		refreshPanel(m, nil)
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) softwareLabel() string {
+	if m.serverVersion != "" {
+		return m.serverVersion
+	}
+	if m.software == mc.SoftwareUnknown || strings.Contains(m.version, string(m.software)) {
+		return m.version
+	}
+	return string(m.software) + " " + m.version
+}
+
+func (m Model) viewPluginsPanel(width, height int) string {
+	title := "Plugins"
+	if m.software.IsForge() || m.software == mc.SoftwareFabric {
+		title = "Mods"
+	}
+
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		m.styles.playersTitle.Width(width/2).Render(title),
+		lipgloss.NewStyle().
+			Width(width-width/2).
+			Align(lipgloss.Right).
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(m.softwareLabel()),
+	)
+
+	if !m.plugins.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, header, "Loading...")
+	}
+
+	table := m.plugins.table
+	table.rowColor = func(row []string) string {
+		if row[3] == "disabled" {
+			return m.colors.red
+		}
+		return ""
+	}
+	body := table.View(
+		width, height-3,
+		lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+		m.styles.playerLabelSelected,
+	)
+	if m.plugins.note != "" && len(m.plugins.plugins) == 0 {
+		body = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.yellow)).
+			Render(m.plugins.note)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		body,
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[r] Refresh"),
+	)
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/actions"
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const popupOptionsPerRow = 5
+
+// SetActions sets the actions offered in the player popup.
+func (m *Model) SetActions(c actions.Config) {
+	m.popup.actions = c.Actions
+	m.popup.menus = nil
+	m.popup.activeOptionIndex = 0
+}
+
+// options returns the actions of the open menu.
+func (p *Popup) options() []actions.Action {
+	if len(p.menus) > 0 {
+		return p.menus[len(p.menus)-1].Actions
+	}
+	return p.actions
+}
+
+// closeMenu goes back to the parent menu, it reports false at the top.
+func (p *Popup) closeMenu() bool {
+	if len(p.menus) == 0 {
+		return false
+	}
+	last := p.menus[len(p.menus)-1]
+	p.menus = p.menus[:len(p.menus)-1]
+	p.activeOptionIndex = 0
+	for i, a := range p.options() {
+		if a.Label == last.Label {
+			p.activeOptionIndex = i
+		}
+	}
+	return true
+}
+
+func (m Model) actionColor(color string) string {
+	switch color {
+	case "", "dimmed":
+		return m.colors.textDimmed()
+	case "red":
+		return m.colors.red
+	case "green":
+		return m.colors.green
+	case "yellow":
+		return m.colors.yellow
+	}
+	return color
+}
+
+// selectPopupOption opens the selected menu or runs the selected action on
+// the player in the popup.
+func (m *Model) selectPopupOption() {
+	options := m.popup.options()
+	if len(options) == 0 {
+		return
+	}
+	a := options[m.popup.activeOptionIndex]
+	if a.IsMenu() {
+		m.popup.menus = append(m.popup.menus, a)
+		m.popup.activeOptionIndex = 0
+		return
+	}
+
+	p := m.popup.player
+	values := map[string]string{}
+	var asked []actions.Param
+	for _, param := range a.Used() {
+		switch param.Type {
+		case actions.ParamPlayer:
+			values[param.Name] = p.Nickname
+		case actions.ParamUUID:
+			if p.UUID == "" {
+				m.err = fmt.Errorf("%s: UUID of %s is not known", a.Label, p.Nickname)
+				return
+			}
+			values[param.Name] = p.UUID
+		case actions.ParamPos:
+			if p.Dimension == "" {
+				m.err = fmt.Errorf("%s: position of %s is not known yet", a.Label, p.Nickname)
+				return
+			}
+			values[param.Name] = fmt.Sprintf("%.2f %.2f %.2f", p.Pos.X, p.Pos.Y, p.Pos.Z)
+		default:
+			asked = append(asked, param)
+		}
+	}
+
+	m.popup.shown = false
+	m.popup.menus = nil
+	m.askActionParams(a, asked, values)
+}
+
+// askActionParams asks for the remaining parameters one after another, then
+// runs the action.
+func (m *Model) askActionParams(a actions.Action, asked []actions.Param, values map[string]string) {
+	if len(asked) == 0 {
+		m.runAction(a, values)
+		return
+	}
+
+	param, rest := asked[0], asked[1:]
+	label := param.Label
+	if label == "" {
+		label = a.Label + ": " + param.Name
+	}
+	submit := func(m *Model, value string) {
+		values[param.Name] = value
+		m.askActionParams(a, rest, values)
+	}
+
+	if param.Type == actions.ParamChoice {
+		m.AskChoice(label, param.Choices, submit)
+		return
+	}
+	if param.Optional {
+		label += " (optional)"
+	}
+	if param.Type == actions.ParamReason {
+		m.AskReason(label, param.Presets, submit)
+	} else {
+		m.AskPrompt(label, param.Placeholder, submit)
+	}
+	m.prompt.optional = param.Optional
+}
+
+func (m *Model) runAction(a actions.Action, values map[string]string) {
+	cmd := a.Expand(values)
+	player := mc.Player{Name: m.popup.player.Nickname, UUID: m.popup.player.UUID}
+	name, _, _ := strings.Cut(cmd, " ")
+	after := func(m *Model, resps []string) {
+		for _, p := range a.Used() {
+			if p.Type == actions.ParamReason {
+				m.saveReason(values[p.Name])
+			}
+		}
+		if name == "op" || name == "deop" {
+			m.RecordOpResponse(player, resps[0])
+		}
+		refreshData(m, resps)
+	}
+
+	if a.Confirm {
+		m.AskExplicitConfirm(fmt.Sprintf("%s %s?", a.Label, m.popup.player.Nickname), []string{cmd}, after)
+		return
+	}
+	m.RunCmds([]string{cmd}, after)
+}
+
+// viewPopupOptions renders the actions of the open menu, a few per row.
+func (m Model) viewPopupOptions() string {
+	options := m.popup.options()
+	perRow := min(len(options), popupOptionsPerRow)
+	if perRow == 0 {
+		return ""
+	}
+
+	option := lipgloss.NewStyle().
+		Bold(true).
+		Width((m.popup.width - 4) / perRow).
+		Align(lipgloss.Center)
+
+	var rows, row []string
+	for i, o := range options {
+		label := o.Label
+		if o.IsMenu() {
+			label += " ›"
+		}
+		if m.popup.activeOptionIndex == i {
+			row = append(row, option.Background(lipgloss.Color(m.actionColor(o.Color))).Render(label))
+		} else {
+			row = append(row, option.Render(label))
+		}
+		if len(row) == perRow || i == len(options)-1 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Center, row...))
+			row = nil
+		}
+	}
+
+	if len(m.popup.menus) > 0 {
+		var path []string
+		for _, menu := range m.popup.menus {
+			path = append(path, menu.Label)
+		}
+		crumbs := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render(strings.Join(path, " › ") + " | [esc] Back")
+		rows = append([]string{crumbs}, rows...)
+	}
+
+	return lipgloss.NewStyle().MarginTop(1).Render(lipgloss.JoinVertical(lipgloss.Top, rows...))
+}
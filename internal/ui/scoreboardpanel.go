@@ -0,0 +1,323 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ScoreboardPanel struct {
+	objectives []mc.Objective
+	slots      map[string]string
+	active     int
+
+	// entity -> objective display name -> score
+	scores map[string]map[string]int
+
+	table  Table
+	loaded bool
+}
+
+func NewScoreboardPanel() ScoreboardPanel {
+	return ScoreboardPanel{
+		table: NewTable(
+			Column{Title: "#", Width: 4},
+			Column{Title: "Entity"},
+			Column{Title: "Score", Width: 10},
+		),
+	}
+}
+
+func (m *Model) FetchScoreboard() {
+	resp, err := m.rcon.Exec("scoreboard objectives list")
+	if err != nil {
+		m.err = err
+		return
+	}
+	names, err := mc.ParseObjectivesList(resp)
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	// scoreboard.dat maps display names back to objective names
+	stored := map[string]mc.Objective{}
+	m.scoreboard.slots = nil
+	if m.worldDir != "" {
+		if objectives, slots, err := mc.ReadScoreboard(m.worldDir); err == nil {
+			for _, o := range objectives {
+				if o.DisplayName == "" {
+					o.DisplayName = o.Name
+				}
+				stored[o.DisplayName] = o
+			}
+			m.scoreboard.slots = slots
+		}
+	}
+
+	m.scoreboard.objectives = nil
+	for _, n := range names {
+		o, ok := stored[n]
+		if !ok {
+			o = mc.Objective{Name: n, DisplayName: n}
+		}
+		m.scoreboard.objectives = append(m.scoreboard.objectives, o)
+	}
+	if m.scoreboard.active >= len(m.scoreboard.objectives) {
+		m.scoreboard.active = 0
+	}
+
+	resp, err = m.rcon.Exec("scoreboard players list")
+	if err != nil {
+		m.err = err
+		return
+	}
+	entities, err := mc.ParseTrackedEntities(resp)
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	m.scoreboard.scores = map[string]map[string]int{}
+	for _, e := range entities {
+		resp, err := m.rcon.Exec("scoreboard players list " + e)
+		if err != nil {
+			m.err = err
+			return
+		}
+		scores, err := mc.ParseEntityScores(resp)
+		if err != nil {
+			continue
+		}
+		m.scoreboard.scores[e] = scores
+	}
+
+	m.buildLeaderboard()
+	m.scoreboard.loaded = true
+}
+
+func (m *Model) activeObjective() (mc.Objective, bool) {
+	if len(m.scoreboard.objectives) == 0 {
+		return mc.Objective{}, false
+	}
+	return m.scoreboard.objectives[m.scoreboard.active], true
+}
+
+func (m *Model) buildLeaderboard() {
+	obj, ok := m.activeObjective()
+	if !ok {
+		m.scoreboard.table.SetRows(nil)
+		return
+	}
+
+	type entry struct {
+		entity string
+		score  int
+	}
+	var entries []entry
+	for e, scores := range m.scoreboard.scores {
+		if v, ok := scores[obj.DisplayName]; ok {
+			entries = append(entries, entry{e, v})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].score != entries[j].score {
+			return entries[i].score > entries[j].score
+		}
+		return entries[i].entity < entries[j].entity
+	})
+
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{strconv.Itoa(i + 1), e.entity, strconv.Itoa(e.score)}
+	}
+	m.scoreboard.table.SetRows(rows)
+}
+
+// refreshScore re-reads a single score after it was changed.
+func (m *Model) refreshScore(entity string, obj mc.Objective) {
+	get := fmt.Sprintf("scoreboard players get %s %s", entity, obj.Name)
+	m.queue(m.execCmd([]string{get}, func(m *Model, resps []string) {
+		if m.scoreboard.scores == nil {
+			m.scoreboard.scores = map[string]map[string]int{}
+		}
+		if m.scoreboard.scores[entity] == nil {
+			m.scoreboard.scores[entity] = map[string]int{}
+		}
+		v, err := mc.ParseScoreboardInt(resps[0])
+		if err != nil {
+			delete(m.scoreboard.scores[entity], obj.DisplayName)
+		} else {
+			m.scoreboard.scores[entity][obj.DisplayName] = v
+		}
+		m.buildLeaderboard()
+	}, true))
+}
+
+func (m *Model) updateScoreboardPanel(msg tea.KeyMsg) bool {
+	if m.scoreboard.table.Update(msg) {
+		return true
+	}
+
+	obj, hasObj := m.activeObjective()
+	row, hasRow := m.scoreboard.table.Selected()
+
+	switch msg.String() {
+	case "[", "]":
+		n := len(m.scoreboard.objectives)
+		if n == 0 {
+			return true
+		}
+		if msg.String() == "]" {
+			m.scoreboard.active = (m.scoreboard.active + 1) % n
+		} else {
+			m.scoreboard.active = (m.scoreboard.active - 1 + n) % n
+		}
+		m.buildLeaderboard()
+
+	case "s", "a":
+		if !hasObj || !hasRow {
+			return true
+		}
+		entity := row[1]
+		op, label := "set", "Set score of "+entity+" to"
+		if msg.String() == "a" {
+			op, label = "add", "Add to score of "+entity+" (negative to remove)"
+		}
+		m.AskPrompt(label, "0", func(m *Model, value string) {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				m.err = fmt.Errorf("invalid score %q", value)
+				return
+			}
+			op := op
+			// add only takes positive amounts
+			if op == "add" && n < 0 {
+				op, n = "remove", -n
+			}
+			m.RunCmds([]string{fmt.Sprintf("scoreboard players %s %s %s %d", op, entity, obj.Name, n)}, func(m *Model, _ []string) {
+				m.refreshScore(entity, obj)
+			})
+		})
+
+	case "n":
+		if !hasObj {
+			return true
+		}
+		m.AskPrompt("Set score: <entity> <value>", "Steve 10", func(m *Model, value string) {
+			parts := strings.Fields(value)
+			if len(parts) != 2 {
+				m.err = fmt.Errorf("expected <entity> <value>, got %q", value)
+				return
+			}
+			n, err := strconv.Atoi(parts[1])
+			if err != nil {
+				m.err = fmt.Errorf("invalid score %q", parts[1])
+				return
+			}
+			m.RunCmds([]string{fmt.Sprintf("scoreboard players set %s %s %d", parts[0], obj.Name, n)}, func(m *Model, _ []string) {
+				m.refreshScore(parts[0], obj)
+			})
+		})
+
+	case "x", "delete":
+		if !hasObj || !hasRow {
+			return true
+		}
+		entity := row[1]
+		m.AskConfirm(
+			"Reset "+obj.Name+" for "+entity+"?",
+			[]string{fmt.Sprintf("scoreboard players reset %s %s", entity, obj.Name)},
+			func(m *Model, _ []string) { m.refreshScore(entity, obj) },
+		)
+
+	case "D":
+		if !hasObj {
+			return true
+		}
+		m.AskPrompt("Display "+obj.Name+" in slot (sidebar, list, below_name, sidebar.team.<color>)", "sidebar", func(m *Model, slot string) {
+			m.RunCmds([]string{fmt.Sprintf("scoreboard objectives setdisplay %s %s", slot, obj.Name)}, func(m *Model, _ []string) {
+				if m.scoreboard.slots == nil {
+					m.scoreboard.slots = map[string]string{}
+				}
+				m.scoreboard.slots[slot] = obj.Name
+			})
+		})
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewScoreboardPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Scoreboard")
+
+	if !m.scoreboard.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+	if len(m.scoreboard.objectives) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "No objectives. Create one with /scoreboard objectives add.")
+	}
+
+	// objective selector, the active one is highlighted
+	var objs []string
+	for i, o := range m.scoreboard.objectives {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if i == m.scoreboard.active {
+			style = m.styles.playerLabelSelected.Padding(0, 1)
+		}
+		objs = append(objs, style.Render(o.DisplayName))
+	}
+
+	obj := m.scoreboard.objectives[m.scoreboard.active]
+	var info []string
+	if obj.Name != obj.DisplayName {
+		info = append(info, "name: "+obj.Name)
+	}
+	if obj.Criteria != "" {
+		info = append(info, "criteria: "+obj.Criteria)
+	}
+	for slot, name := range m.scoreboard.slots {
+		if name == obj.Name {
+			info = append(info, "shown in: "+slot)
+		}
+	}
+	sort.Strings(info)
+
+	table := m.scoreboard.table
+	table.rowColor = func(row []string) string {
+		switch row[0] {
+		case "1":
+			return m.colors.yellow
+		case "2", "3":
+			return m.colors.text()
+		}
+		return ""
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		lipgloss.NewStyle().Width(width).MaxHeight(1).Render(strings.Join(objs, "")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.textDimmed())).Render(strings.Join(info, " | ")),
+		table.View(
+			width, height-5,
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[ / ] Objective | [s] Set | [a] Add | [n] New | [x] Reset | [D] Display slot | [r] Refresh"),
+	)
+}
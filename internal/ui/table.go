@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Column struct {
+	Title string
+	Width int // 0 = take the remaining width
+}
+
+// Table is a scrollable, filterable list used by the panel tabs.
+type Table struct {
+	columns []Column
+	rows    [][]string
+
+	// optional per row foreground color
+	rowColor func(row []string) string
+
+	filter    textinput.Model
+	filtering bool
+
+	cursor int
+	offset int
+}
+
+func NewTable(columns ...Column) Table {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "search"
+	ti.CharLimit = 64
+
+	return Table{
+		columns: columns,
+		filter:  ti,
+	}
+}
+
+func (t *Table) SetRows(rows [][]string) {
+	t.rows = rows
+	t.clamp()
+}
+
+func (t Table) Rows() [][]string {
+	return t.rows
+}
+
+// visible returns the indexes of rows matching the filter.
+func (t Table) visible() []int {
+	q := strings.ToLower(strings.TrimSpace(t.filter.Value()))
+
+	var out []int
+	for i, r := range t.rows {
+		if q == "" || strings.Contains(strings.ToLower(strings.Join(r, " ")), q) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func (t Table) Selected() ([]string, bool) {
+	vis := t.visible()
+	if t.cursor < 0 || t.cursor >= len(vis) {
+		return nil, false
+	}
+	return t.rows[vis[t.cursor]], true
+}
+
+// SelectedIndex returns the index of the selected row in the unfiltered rows.
+func (t Table) SelectedIndex() int {
+	vis := t.visible()
+	if t.cursor < 0 || t.cursor >= len(vis) {
+		return -1
+	}
+	return vis[t.cursor]
+}
+
+func (t *Table) clamp() {
+	n := len(t.visible())
+	if t.cursor >= n {
+		t.cursor = n - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+}
+
+// Filtered reports whether a search hides rows.
+func (t Table) Filtered() bool {
+	return strings.TrimSpace(t.filter.Value()) != ""
+}
+
+// Filtering reports whether keys are currently typed into the search box.
+func (t Table) Filtering() bool {
+	return t.filtering
+}
+
+// Update handles navigation and search keys. It returns false for keys the
+// table does not use.
+func (t *Table) Update(msg tea.KeyMsg) bool {
+	if t.filtering {
+		switch msg.String() {
+		case "enter", "down", "up":
+			t.filtering = false
+			t.filter.Blur()
+		case "esc":
+			t.filtering = false
+			t.filter.Blur()
+			t.filter.SetValue("")
+		case "ctrl+c", "tab":
+			t.filtering = false
+			t.filter.Blur()
+			return false
+		default:
+			t.filter, _ = t.filter.Update(msg)
+		}
+		t.clamp()
+		return true
+	}
+
+	switch msg.String() {
+	case "/":
+		t.filtering = true
+		t.filter.Focus()
+	case "esc":
+		if t.filter.Value() == "" {
+			return false
+		}
+		t.filter.SetValue("")
+	case "up":
+		t.cursor--
+	case "down":
+		t.cursor++
+	case "pgup":
+		t.cursor -= 10
+	case "pgdown":
+		t.cursor += 10
+	case "home":
+		t.cursor = 0
+	case "end":
+		t.cursor = len(t.visible()) - 1
+	default:
+		return false
+	}
+
+	t.clamp()
+	return true
+}
+
+func (t *Table) View(width, height int, header, selected lipgloss.Style) string {
+	if height < 3 {
+		height = 3
+	}
+
+	fixed := 0
+	flex := 0
+	for _, c := range t.columns {
+		if c.Width == 0 {
+			flex++
+		}
+		fixed += c.Width + 1
+	}
+	flexWidth := 0
+	if flex > 0 {
+		flexWidth = (width - fixed) / flex
+		if flexWidth < 4 {
+			flexWidth = 4
+		}
+	}
+
+	cell := func(i int, s string) string {
+		w := t.columns[i].Width
+		if w == 0 {
+			w = flexWidth
+		}
+		return lipgloss.NewStyle().Width(w).MaxWidth(w).MarginRight(1).Render(truncate(s, w))
+	}
+
+	var titles []string
+	for i, c := range t.columns {
+		titles = append(titles, cell(i, c.Title))
+	}
+
+	lines := []string{header.Render(lipgloss.JoinHorizontal(lipgloss.Left, titles...))}
+
+	// header + search line
+	rowsHeight := height - 2
+	vis := t.visible()
+
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+rowsHeight {
+		t.offset = t.cursor - rowsHeight + 1
+	}
+	if t.offset > len(vis)-rowsHeight {
+		t.offset = max(len(vis)-rowsHeight, 0)
+	}
+
+	for n := t.offset; n < len(vis) && n < t.offset+rowsHeight; n++ {
+		row := t.rows[vis[n]]
+
+		var cells []string
+		for i := range t.columns {
+			v := ""
+			if i < len(row) {
+				v = row[i]
+			}
+			cells = append(cells, cell(i, v))
+		}
+		line := lipgloss.JoinHorizontal(lipgloss.Left, cells...)
+
+		style := lipgloss.NewStyle()
+		if t.rowColor != nil {
+			if c := t.rowColor(row); c != "" {
+				style = style.Foreground(lipgloss.Color(c))
+			}
+		}
+		if n == t.cursor {
+			style = selected.Inherit(style)
+		}
+		lines = append(lines, style.Width(width).Render(line))
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+
+	footer := t.filter.View()
+	if !t.filtering && t.filter.Value() == "" {
+		footer = header.UnsetBold().Render(
+			"[/] search  " + itoa(len(vis)) + " of " + itoa(len(t.rows)),
+		)
+	} else {
+		footer += "  " + itoa(len(vis)) + " of " + itoa(len(t.rows))
+	}
+	lines = append(lines, footer)
+
+	return strings.Join(lines, "\n")
+}
+
+func truncate(s string, w int) string {
+	if lipgloss.Width(s) <= w {
+		return s
+	}
+	r := []rune(s)
+	if w <= 1 || len(r) <= w-1 {
+		return string(r[:min(len(r), w)])
+	}
+	return string(r[:w-1]) + "…"
+}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// team options in the order they are shown
+var teamOptions = []string{
+	"color",
+	"friendlyFire",
+	"seeFriendlyInvisibles",
+	"nametagVisibility",
+	"deathMessageVisibility",
+	"collisionRule",
+}
+
+type TeamsPanel struct {
+	teams  []mc.Team
+	active int
+
+	table  Table
+	loaded bool
+}
+
+func NewTeamsPanel() TeamsPanel {
+	return TeamsPanel{
+		table: NewTable(Column{Title: "Member"}),
+	}
+}
+
+func (m *Model) FetchTeams() {
+	resp, err := m.rcon.Exec("team list")
+	if err != nil {
+		m.err = err
+		return
+	}
+	names, err := mc.ParseTeamList(resp)
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	stored := map[string]mc.Team{}
+	if m.worldDir != "" {
+		if teams, err := mc.ReadTeams(m.worldDir); err == nil {
+			for _, t := range teams {
+				stored[t.DisplayName] = t
+			}
+		}
+	}
+
+	// options changed from the panel are kept when the file is not available
+	previous := map[string]mc.Team{}
+	for _, t := range m.teams.teams {
+		previous[t.Name] = t
+	}
+
+	m.teams.teams = nil
+	for _, n := range names {
+		t, ok := stored[n]
+		if !ok {
+			t = mc.Team{Name: n, DisplayName: n, Options: map[string]string{}}
+			if p, ok := previous[n]; ok {
+				t.Options = p.Options
+			}
+		}
+
+		// members from the file can be stale, ask the server
+		resp, err := m.rcon.Exec("team list " + t.Name)
+		if err != nil {
+			m.err = err
+			return
+		}
+		if members, err := mc.ParseTeamMembers(resp); err == nil {
+			t.Members = members
+		}
+
+		m.teams.teams = append(m.teams.teams, t)
+	}
+
+	if m.teams.active >= len(m.teams.teams) {
+		m.teams.active = 0
+	}
+	m.buildTeamMembers()
+	m.teams.loaded = true
+}
+
+func (m *Model) activeTeam() (*mc.Team, bool) {
+	if len(m.teams.teams) == 0 {
+		return nil, false
+	}
+	return &m.teams.teams[m.teams.active], true
+}
+
+func (m *Model) buildTeamMembers() {
+	team, ok := m.activeTeam()
+	if !ok {
+		m.teams.table.SetRows(nil)
+		return
+	}
+
+	members := append([]string(nil), team.Members...)
+	sort.Strings(members)
+
+	rows := make([][]string, len(members))
+	for i, p := range members {
+		rows[i] = []string{p}
+	}
+	m.teams.table.SetRows(rows)
+}
+
+func (m *Model) updateTeamsPanel(msg tea.KeyMsg) bool {
+	if m.teams.table.Update(msg) {
+		return true
+	}
+
+	team, hasTeam := m.activeTeam()
+	row, hasRow := m.teams.table.Selected()
+
+	switch msg.String() {
+	case "[", "]":
+		n := len(m.teams.teams)
+		if n == 0 {
+			return true
+		}
+		if msg.String() == "]" {
+			m.teams.active = (m.teams.active + 1) % n
+		} else {
+			m.teams.active = (m.teams.active - 1 + n) % n
+		}
+		m.buildTeamMembers()
+
+	case "c":
+		m.AskPrompt("Create team", "name", func(m *Model, name string) {
+			m.RunCmds([]string{"team add " + name}, refreshPanel)
+		})
+
+	case "j":
+		if !hasTeam {
+			return true
+		}
+		name := team.Name
+		m.AskPrompt("Players to join "+name+" (space separated or a selector)", "Steve Alex", func(m *Model, players string) {
+			m.RunCmds([]string{fmt.Sprintf("team join %s %s", name, players)}, refreshPanel)
+		})
+
+	case "l", "delete":
+		if !hasRow {
+			return true
+		}
+		m.RunCmds([]string{"team leave " + row[0]}, refreshPanel)
+
+	case "o":
+		if !hasTeam {
+			return true
+		}
+		name := team.Name
+		m.AskPrompt("Modify "+name+": <option> <value>", "friendlyFire false", func(m *Model, value string) {
+			parts := strings.SplitN(value, " ", 2)
+			if len(parts) != 2 {
+				m.err = fmt.Errorf("expected <option> <value>, got %q", value)
+				return
+			}
+			m.RunCmds([]string{fmt.Sprintf("team modify %s %s %s", name, parts[0], parts[1])}, func(m *Model, resps []string) {
+				if mc.ClassifyResponse(resps[0]) == nil {
+					if t, ok := m.activeTeam(); ok && t.Name == name {
+						t.Options[parts[0]] = parts[1]
+					}
+				}
+			})
+		})
+
+	case "e":
+		if !hasTeam {
+			return true
+		}
+		m.AskConfirm("Remove all members from "+team.Name+"?", []string{"team empty " + team.Name}, refreshPanel)
+
+	case "x":
+		if !hasTeam {
+			return true
+		}
+		m.AskConfirm("Delete team "+team.Name+"?", []string{"team remove " + team.Name}, refreshPanel)
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewTeamsPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Teams")
+	help := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.colors.textDimmed())).
+		Render("[ / ] Team | [c] Create | [j] Join | [l] Leave | [o] Option | [e] Empty | [x] Delete | [r] Refresh")
+
+	if !m.teams.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+	if len(m.teams.teams) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "No teams.", "", help)
+	}
+
+	var names []string
+	for i, t := range m.teams.teams {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if c, ok := m.mcColor(t.Options["color"]); ok {
+			style = style.Foreground(lipgloss.Color(c))
+		}
+		if i == m.teams.active {
+			style = style.Inherit(m.styles.playerLabelSelected)
+		}
+		names = append(names, style.Render(t.DisplayName))
+	}
+
+	team := m.teams.teams[m.teams.active]
+
+	label := lipgloss.NewStyle().Width(24).Foreground(lipgloss.Color(m.colors.textDimmed()))
+	var options []string
+	for _, o := range teamOptions {
+		v, ok := team.Options[o]
+		if !ok || v == "" {
+			v = "?"
+		}
+		options = append(options, label.Render(o+":")+v)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		lipgloss.NewStyle().Width(width).MaxHeight(1).Render(strings.Join(names, "")),
+		lipgloss.JoinVertical(lipgloss.Top, options...),
+		m.teams.table.View(
+			width, height-5-len(options),
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		help,
+	)
+}
+
+// mcColors maps Minecraft color names to their RGB values.
+var mcColors = map[string]string{
+	"black":        "#000000",
+	"dark_blue":    "#0000AA",
+	"dark_green":   "#00AA00",
+	"dark_aqua":    "#00AAAA",
+	"dark_red":     "#AA0000",
+	"dark_purple":  "#AA00AA",
+	"gold":         "#FFAA00",
+	"gray":         "#AAAAAA",
+	"dark_gray":    "#555555",
+	"blue":         "#5555FF",
+	"green":        "#55FF55",
+	"aqua":         "#55FFFF",
+	"red":          "#FF5555",
+	"light_purple": "#FF55FF",
+	"yellow":       "#FFFF55",
+	"white":        "#FFFFFF",
+}
+
+// mcColorsLight replaces the Minecraft colors that are too light to read on
+// a light background.
+var mcColorsLight = map[string]string{
+	"gold":         "#E67700",
+	"gray":         "#868E96",
+	"green":        "#2B8A3E",
+	"aqua":         "#0B7285",
+	"light_purple": "#AE3EC9",
+	"yellow":       "#B08800",
+	"white":        "#495057",
+}
+
+// mcColor returns the RGB value of a Minecraft color for the theme.
+func (m Model) mcColor(name string) (string, bool) {
+	if c, ok := mcColorsLight[name]; ok && !m.colors.dark {
+		return c, true
+	}
+	c, ok := mcColors[name]
+	return c, ok
+}
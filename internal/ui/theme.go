@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"sebpok/mc-rcon-tui/internal/history"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultColors returns the built-in palette for a dark or light terminal
+// background.
+func DefaultColors(dark bool) *Colors {
+	c := &Colors{
+		textDark:         "#eebefa",
+		textActiveDark:   "#f8f0fc",
+		textDimmedDark:   "#555555",
+		borderDark:       "#666666",
+		borderActiveDark: "#da77f2",
+
+		textBright:         "#862e9c",
+		textActiveBright:   "#212529",
+		textDimmedBright:   "#adb5bd",
+		borderBright:       "#adb5bd",
+		borderActiveBright: "#ae3ec9",
+
+		dark: dark,
+	}
+	if dark {
+		c.green, c.yellow, c.red = "#37b24d", "#f59f00", "#f03e3e"
+		c.orange, c.mint = "#d7875f", "#87d787"
+	} else {
+		c.green, c.yellow, c.red = "#2f9e44", "#e67700", "#e03131"
+		c.orange, c.mint = "#d9480f", "#2b8a3e"
+	}
+	return c
+}
+
+func (c *Colors) text() string {
+	if c.dark {
+		return c.textDark
+	}
+	return c.textBright
+}
+
+func (c *Colors) textActive() string {
+	if c.dark {
+		return c.textActiveDark
+	}
+	return c.textActiveBright
+}
+
+func (c *Colors) textDimmed() string {
+	if c.dark {
+		return c.textDimmedDark
+	}
+	return c.textDimmedBright
+}
+
+func (c *Colors) border() string {
+	if c.dark {
+		return c.borderDark
+	}
+	return c.borderBright
+}
+
+func (c *Colors) borderActive() string {
+	if c.dark {
+		return c.borderActiveDark
+	}
+	return c.borderActiveBright
+}
+
+// paletteVariant is the part of a palette file that differs between dark
+// and light backgrounds.
+type paletteVariant struct {
+	Text         string `json:"text"`
+	TextActive   string `json:"textActive"`
+	TextDimmed   string `json:"textDimmed"`
+	Border       string `json:"border"`
+	BorderActive string `json:"borderActive"`
+}
+
+// palette is a theme file, e.g.
+//
+//	{"dark": {"text": "#a5d8ff", "borderActive": "#339af0"}, "red": "#ff6b6b"}
+//
+// Colors left out keep their default.
+type palette struct {
+	Dark  paletteVariant `json:"dark"`
+	Light paletteVariant `json:"light"`
+
+	Green  string `json:"green"`
+	Yellow string `json:"yellow"`
+	Red    string `json:"red"`
+	Orange string `json:"orange"`
+	Mint   string `json:"mint"`
+}
+
+// hex colors or ANSI color numbers
+var reColor = regexp.MustCompile(`^(#[0-9A-Fa-f]{3}|#[0-9A-Fa-f]{6}|[0-9]{1,3})$`)
+
+// LoadTheme returns the colors of a theme: "dark", "light" or "auto", which
+// asks the terminal for its background. The palette file overrides the
+// defaults, with path empty theme.json in the config directory is read if
+// it exists.
+func LoadTheme(theme string, path string) (*Colors, error) {
+	var dark bool
+	switch theme {
+	case "dark":
+		dark = true
+	case "light":
+		dark = false
+	case "auto", "":
+		dark = lipgloss.HasDarkBackground()
+	default:
+		return nil, fmt.Errorf("unknown theme %q, expected dark, light or auto", theme)
+	}
+	c := DefaultColors(dark)
+
+	explicit := path != ""
+	if !explicit {
+		dir, err := history.ConfigDir()
+		if err != nil {
+			return c, nil
+		}
+		path = filepath.Join(dir, "theme.json")
+	}
+
+	data, err := os.ReadFile(path)
+	if !explicit && errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var p palette
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, o := range []struct {
+		name  string
+		value string
+		field *string
+	}{
+		{"dark.text", p.Dark.Text, &c.textDark},
+		{"dark.textActive", p.Dark.TextActive, &c.textActiveDark},
+		{"dark.textDimmed", p.Dark.TextDimmed, &c.textDimmedDark},
+		{"dark.border", p.Dark.Border, &c.borderDark},
+		{"dark.borderActive", p.Dark.BorderActive, &c.borderActiveDark},
+		{"light.text", p.Light.Text, &c.textBright},
+		{"light.textActive", p.Light.TextActive, &c.textActiveBright},
+		{"light.textDimmed", p.Light.TextDimmed, &c.textDimmedBright},
+		{"light.border", p.Light.Border, &c.borderBright},
+		{"light.borderActive", p.Light.BorderActive, &c.borderActiveBright},
+		{"green", p.Green, &c.green},
+		{"yellow", p.Yellow, &c.yellow},
+		{"red", p.Red, &c.red},
+		{"orange", p.Orange, &c.orange},
+		{"mint", p.Mint, &c.mint},
+	} {
+		if o.value == "" {
+			continue
+		}
+		if !reColor.MatchString(o.value) {
+			return nil, fmt.Errorf("%s: invalid color %q for %s", path, o.value, o.name)
+		}
+		*o.field = o.value
+	}
+	return c, nil
+}
+
+// SetTheme sets the colors used by every style.
+func (m *Model) SetTheme(c *Colors) {
+	m.colors = c
+	m.styles = NewStyles(c)
+	m.input.PlaceholderStyle = lipgloss.NewStyle().Foreground(m.styles.textDimmedColor)
+	// the bubbles components pick their adaptive colors by this
+	lipgloss.SetHasDarkBackground(c.dark)
+}
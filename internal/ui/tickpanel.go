@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// a time argument: ticks, or a number with the unit t, s or d
+var reTickTime = regexp.MustCompile(`^(\d+(?:\.\d+)?)[tsd]?$`)
+
+type TickPanel struct {
+	query       mc.TickQuery
+	loaded      bool
+	unsupported bool
+}
+
+func (m *Model) FetchTickState() {
+	resp, err := m.rcon.Exec("tick query")
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	q, err := mc.ParseTickQuery(resp)
+	if errors.Is(err, mc.ErrUnknownCommand) {
+		m.tick.unsupported = true
+		return
+	}
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	m.tick.query = q
+	m.tick.loaded = true
+	m.tick.unsupported = false
+}
+
+func (m *Model) updateTickPanel(msg tea.KeyMsg) bool {
+	if m.tick.unsupported {
+		return false
+	}
+
+	switch msg.String() {
+	case "f":
+		if m.tick.query.State == mc.TickFrozen {
+			m.AskConfirm("Unfreeze the game?", []string{"tick unfreeze"}, refreshPanel)
+		} else {
+			m.AskConfirm("Freeze the game? Entities and blocks stop ticking.", []string{"tick freeze"}, refreshPanel)
+		}
+
+	case "s":
+		m.AskPrompt("Step how many ticks? (game must be frozen)", "1", func(m *Model, value string) {
+			if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+				m.err = fmt.Errorf("invalid tick count %q", value)
+				return
+			}
+			m.AskConfirm("Step "+value+" ticks?", []string{"tick step " + value}, refreshPanel)
+		})
+
+	case "S":
+		m.RunCmds([]string{"tick step stop"}, refreshPanel)
+
+	case "r":
+		m.AskPrompt("Target tick rate (1-10000)", "20", func(m *Model, value string) {
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 1 || rate > 10000 {
+				m.err = fmt.Errorf("invalid tick rate %q", value)
+				return
+			}
+			m.AskConfirm(fmt.Sprintf("Set tick rate to %s?", value), []string{"tick rate " + value}, refreshPanel)
+		})
+
+	case "R":
+		m.AskConfirm("Reset tick rate to 20?", []string{"tick rate 20"}, refreshPanel)
+
+	case "p":
+		m.AskPrompt("Sprint for how long? (e.g. 200, 60s, 1d)", "1d", func(m *Model, value string) {
+			match := reTickTime.FindStringSubmatch(value)
+			if match == nil {
+				m.err = fmt.Errorf("invalid sprint time %q, expected ticks or a number with t, s or d", value)
+				return
+			}
+			if n, _ := strconv.ParseFloat(match[1], 64); n <= 0 {
+				m.err = fmt.Errorf("invalid sprint time %q", value)
+				return
+			}
+			m.AskConfirm("Sprint for "+value+"? The server will run as fast as possible.", []string{"tick sprint " + value}, refreshPanel)
+		})
+
+	case "P":
+		m.RunCmds([]string{"tick sprint stop"}, refreshPanel)
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (m Model) viewTickPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Tick manager")
+
+	if m.tick.unsupported {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			title,
+			lipgloss.NewStyle().
+				Foreground(lipgloss.Color(m.colors.yellow)).
+				Render("This server has no /tick command (requires 1.20.3+)."),
+		)
+	}
+	if !m.tick.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	q := m.tick.query
+
+	label := lipgloss.NewStyle().Width(18)
+	value := lipgloss.NewStyle().Bold(true)
+	row := func(l string, v string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Left, label.Render(l), v)
+	}
+
+	var stateColor string
+	switch q.State {
+	case mc.TickRunning:
+		stateColor = m.colors.green
+	case mc.TickLagging:
+		stateColor = m.colors.red
+	default:
+		stateColor = m.colors.yellow
+	}
+
+	barWidth := width - 18 - 14
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	msptBar := func(ms float64) string {
+		color := m.msptColor(ms * 50 / q.TargetMSPT)
+		return value.Foreground(lipgloss.Color(color)).Render(
+			fmt.Sprintf("%s %6.2f ms", AsciiBar(ms/q.TargetMSPT, barWidth, "█", "░"), ms),
+		)
+	}
+
+	rows := []string{
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		row("State:", value.Foreground(lipgloss.Color(stateColor)).Render(string(q.State))),
+		row("Target rate:", value.Render(fmt.Sprintf("%.1f TPS (%.1f ms)", q.TargetRate, q.TargetMSPT))),
+		row("Actual rate:", value.Foreground(lipgloss.Color(m.tpsColor(q.TPS()*20/q.TargetRate))).Render(fmt.Sprintf("%.1f TPS", q.TPS()))),
+		"",
+		row("Avg tick time:", msptBar(q.AvgMSPT)),
+	}
+
+	if q.Samples > 0 {
+		rows = append(rows,
+			row("P50:", msptBar(q.P50)),
+			row("P95:", msptBar(q.P95)),
+			row("P99:", msptBar(q.P99)),
+			row("Samples:", value.Render(strconv.Itoa(q.Samples))),
+		)
+	}
+
+	rows = append(rows,
+		"",
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[f] Freeze/unfreeze | [s/S] Step/stop | [r/R] Rate/reset | [p/P] Sprint/stop"),
+	)
+
+	return lipgloss.NewStyle().
+		Width(width).
+		MaxHeight(height).
+		Render(lipgloss.JoinVertical(lipgloss.Top, rows...))
+}
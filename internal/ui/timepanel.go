@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type WorldTime struct {
+	Clock   string
+	Ticks   int
+	Day     int
+	Weather mc.Weather
+
+	known bool
+}
+
+const (
+	weatherRainPredicate    = `execute if predicate {condition:"minecraft:weather_check",raining:true}`
+	weatherThunderPredicate = `execute if predicate {condition:"minecraft:weather_check",thundering:true}`
+)
+
+func (m *Model) FetchTime() {
+	resp, err := m.rcon.Exec("time query daytime")
+	if err != nil {
+		m.err = err
+		return
+	}
+	ticks, err := mc.ParseTimeTicks(resp)
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.time.Ticks = ticks
+	m.time.Clock = mc.ParseTime(resp)
+
+	resp, err = m.rcon.Exec("time query day")
+	if err != nil {
+		m.err = err
+		return
+	}
+	day, err := mc.ParseTimeTicks(resp)
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.time.Day = day
+	m.time.known = true
+
+	m.time.Weather = m.fetchWeather()
+}
+
+// fetchWeather uses inline predicates (1.20.5+), vanilla has no weather query.
+func (m *Model) fetchWeather() mc.Weather {
+	resp, err := m.rcon.Exec(weatherThunderPredicate)
+	if err != nil {
+		return mc.WeatherUnknown
+	}
+	thunder, _, err := mc.ParseTestResult(resp)
+	if err != nil {
+		return mc.WeatherUnknown
+	}
+	if thunder {
+		return mc.WeatherThunder
+	}
+
+	resp, err = m.rcon.Exec(weatherRainPredicate)
+	if err != nil {
+		return mc.WeatherUnknown
+	}
+	rain, _, err := mc.ParseTestResult(resp)
+	if err != nil {
+		return mc.WeatherUnknown
+	}
+	if rain {
+		return mc.WeatherRain
+	}
+	return mc.WeatherClear
+}
+
+func (m *Model) setTime(value string) {
+	m.RunCmds([]string{"time set " + value}, refreshPanel)
+}
+
+func (m *Model) setWeather(weather mc.Weather) {
+	m.RunCmds([]string{"weather " + string(weather)}, refreshPanel)
+}
+
+func (m *Model) updateTimePanel(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "d":
+		m.setTime("day")
+	case "n":
+		m.setTime("noon")
+	case "N":
+		m.setTime("night")
+	case "m":
+		m.setTime("midnight")
+	case "t":
+		m.AskPrompt("Set time (ticks, or e.g. 6000, 2d, 300s)", "1000", func(m *Model, value string) {
+			m.setTime(value)
+		})
+	case "a":
+		m.AskPrompt("Add time (ticks, or e.g. 1d, 60s)", "1000", func(m *Model, value string) {
+			m.RunCmds([]string{"time add " + value}, refreshPanel)
+		})
+	case "c":
+		m.setWeather(mc.WeatherClear)
+	case "r":
+		m.setWeather(mc.WeatherRain)
+	case "T":
+		m.setWeather(mc.WeatherThunder)
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) weatherText() string {
+	switch m.time.Weather {
+	case mc.WeatherClear:
+		return "☀ clear"
+	case mc.WeatherRain:
+		return "☂ rain"
+	case mc.WeatherThunder:
+		return "⚡ thunder"
+	}
+	return "?"
+}
+
+// renderTime returns the clock and weather rows of the info box.
+func (m Model) renderTime(label, value lipgloss.Style) string {
+	if !m.time.known {
+		return lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			label.Render("Time:"),
+			value.Foreground(lipgloss.Color(m.colors.textDimmed())).Render("-"),
+		)
+	}
+
+	_, moon := mc.MoonPhase(m.time.Day)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			label.Render("Time:"),
+			value.Render(fmt.Sprintf("day %d, %s", m.time.Day, m.time.Clock)),
+		),
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			label.Render("Weather:"),
+			value.Render(m.weatherText()+" "+moon),
+		),
+	)
+}
+
+func (m Model) viewTimePanel(width, height int) string {
+	title := m.styles.playersTitle.Render("Time & weather")
+
+	if !m.time.known {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	label := lipgloss.NewStyle().Width(18)
+	value := lipgloss.NewStyle().Bold(true)
+	row := func(l string, v string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Left, label.Render(l), v)
+	}
+
+	moonName, moonIcon := mc.MoonPhase(m.time.Day)
+
+	phase := "day"
+	if m.time.Ticks >= 12542 && m.time.Ticks < 23460 {
+		// beds can be used in this range
+		phase = "night"
+	}
+
+	rows := []string{
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		row("Clock:", value.Foreground(lipgloss.Color(m.colors.text())).Render(m.time.Clock)),
+		row("Day:", value.Render(strconv.Itoa(m.time.Day))),
+		row("Daytime:", value.Render(fmt.Sprintf("%d ticks (%s)", m.time.Ticks, phase))),
+		row("", AsciiBar(float64(m.time.Ticks)/24000, width-18-2, "█", "░")),
+		row("Moon:", value.Render(moonIcon+" "+moonName)),
+		row("Weather:", value.Render(m.weatherText())),
+		"",
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Width(width).
+			Render("[d] Day | [n] Noon | [N] Night | [m] Midnight | [t] Set | [a] Add | [c] Clear | [r] Rain | [T] Thunder"),
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		MaxHeight(height).
+		Render(lipgloss.JoinVertical(lipgloss.Top, rows...))
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type WhitelistPanel struct {
+	table  Table
+	loaded bool
+
+	// vanilla has no command to query the state, it is only known after a
+	// toggle
+	enabled      bool
+	enabledKnown bool
+}
+
+func NewWhitelistPanel() WhitelistPanel {
+	return WhitelistPanel{
+		table: NewTable(Column{Title: "Player"}),
+	}
+}
+
+func (m *Model) FetchWhitelist() {
+	resp, err := m.rcon.Exec("whitelist list")
+	if err != nil {
+		m.err = err
+		return
+	}
+	names, err := mc.ParseWhitelist(resp)
+	if err != nil {
+		m.err = err
+		return
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+
+	rows := make([][]string, len(names))
+	for i, n := range names {
+		rows[i] = []string{n}
+	}
+	m.whitelist.table.SetRows(rows)
+	m.whitelist.loaded = true
+}
+
+func (m *Model) enableWhitelist() {
+	m.RunCmds([]string{"whitelist on"}, func(m *Model, resps []string) {
+		if enabled, err := mc.ParseWhitelistToggle(resps[0]); err == nil {
+			m.whitelist.enabled = enabled
+			m.whitelist.enabledKnown = true
+		}
+	})
+}
+
+func (m *Model) updateWhitelistPanel(msg tea.KeyMsg) bool {
+	if m.whitelist.table.Update(msg) {
+		return true
+	}
+
+	switch msg.String() {
+	case "a":
+		m.AskPrompt("Add player to whitelist", "nickname", func(m *Model, value string) {
+			m.RunCmds([]string{"whitelist add " + value}, refreshPanel)
+		})
+
+	case "d", "delete":
+		row, ok := m.whitelist.table.Selected()
+		if !ok {
+			return true
+		}
+		m.AskConfirm("Remove "+row[0]+" from the whitelist?", []string{"whitelist remove " + row[0]}, refreshPanel)
+
+	case "o":
+		m.enableWhitelist()
+
+	case "O":
+		m.AskConfirm("Turn the whitelist off? Anyone will be able to join.", []string{"whitelist off"}, func(m *Model, _ []string) {
+			m.whitelist.enabled = false
+			m.whitelist.enabledKnown = true
+		})
+
+	case "R":
+		m.RunCmds([]string{"whitelist reload"}, refreshPanel)
+
+	case "r":
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (m Model) viewWhitelistPanel(width, height int) string {
+	state := "state unknown"
+	stateColor := m.colors.textDimmed()
+	if m.whitelist.enabledKnown {
+		if m.whitelist.enabled {
+			state, stateColor = "on", m.colors.green
+		} else {
+			state, stateColor = "off", m.colors.red
+		}
+	}
+
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		m.styles.playersTitle.Width(width/2).Render("Whitelist"),
+		lipgloss.NewStyle().
+			Width(width-width/2).
+			Align(lipgloss.Right).
+			Foreground(lipgloss.Color(stateColor)).
+			Render(state),
+	)
+
+	if !m.whitelist.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, header, "Loading...")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		m.whitelist.table.View(
+			width, height-3,
+			lipgloss.NewStyle().Bold(true).Foreground(m.styles.textDimmedColor),
+			m.styles.playerLabelSelected,
+		),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[a] Add | [d] Remove | [o/O] On/off | [R] Reload | [r] Refresh"),
+	)
+}
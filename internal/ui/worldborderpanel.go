@@ -0,0 +1,397 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"sebpok/mc-rcon-tui/internal/mc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type borderPlayer struct {
+	name   string
+	x, z   float64
+	nether bool
+}
+
+type WorldBorderPanel struct {
+	border mc.WorldBorder
+
+	// center and damage are only known from level.dat or after setting them
+	fromFile    bool
+	centerKnown bool
+
+	players        []borderPlayer
+	playersFetched time.Time
+
+	// running grow/shrink started from the panel
+	moveFrom     float64
+	moveTo       float64
+	moveStart    time.Time
+	moveDuration time.Duration
+
+	loaded bool
+}
+
+func (m *Model) FetchWorldBorder() {
+	resp, err := m.rcon.Exec("worldborder get")
+	if err != nil {
+		m.err = err
+		return
+	}
+	size, err := mc.ParseWorldBorderSize(resp)
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	if m.worldDir != "" && !m.worldborder.loaded {
+		if b, err := mc.ReadWorldBorder(m.worldDir); err == nil {
+			m.worldborder.border = b
+			m.worldborder.fromFile = true
+			m.worldborder.centerKnown = true
+		}
+	}
+	m.worldborder.border.Size = size
+
+	// positions are fetched less often, they need two commands per player
+	if time.Since(m.worldborder.playersFetched) >= 3*time.Second {
+		m.fetchBorderPlayers()
+	}
+
+	m.worldborder.loaded = true
+}
+
+func (m *Model) fetchBorderPlayers() {
+	m.worldborder.playersFetched = time.Now()
+
+	var players []borderPlayer
+	for _, item := range m.players.Items() {
+		name := item.(playerItem).Name
+
+		resp, err := m.rcon.Exec(fmt.Sprintf("data get entity %s Pos", name))
+		if err != nil {
+			m.err = err
+			return
+		}
+		pos, err := mc.ParsePosition(resp)
+		if err != nil {
+			continue
+		}
+
+		resp, err = m.rcon.Exec(fmt.Sprintf("data get entity %s Dimension", name))
+		if err != nil {
+			m.err = err
+			return
+		}
+		dim, _ := mc.ParseDimension(resp)
+
+		p := borderPlayer{name: name, x: pos.X, z: pos.Z}
+		switch dim {
+		case "the_nether":
+			// the border is scaled with the nether coordinate scale
+			p.x, p.z, p.nether = pos.X*8, pos.Z*8, true
+		case "overworld":
+		default:
+			continue
+		}
+		players = append(players, p)
+	}
+	m.worldborder.players = players
+}
+
+func (m *Model) startBorderMove(target float64, seconds float64) {
+	if seconds <= 0 {
+		m.worldborder.moveDuration = 0
+		return
+	}
+	m.worldborder.moveFrom = m.worldborder.border.Size
+	m.worldborder.moveTo = target
+	m.worldborder.moveStart = time.Now()
+	m.worldborder.moveDuration = time.Duration(seconds * float64(time.Second))
+}
+
+// parseSizeAndTime parses "<size> [seconds]".
+func parseSizeAndTime(value string) (size float64, seconds float64, err error) {
+	parts := strings.Fields(value)
+	if len(parts) == 0 || len(parts) > 2 {
+		return 0, 0, fmt.Errorf("expected <blocks> [seconds], got %q", value)
+	}
+	if size, err = strconv.ParseFloat(parts[0], 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid size %q", parts[0])
+	}
+	if len(parts) == 2 {
+		if seconds, err = strconv.ParseFloat(parts[1], 64); err != nil || seconds < 0 {
+			return 0, 0, fmt.Errorf("invalid duration %q", parts[1])
+		}
+	}
+	return size, seconds, nil
+}
+
+func (m *Model) updateWorldBorderPanel(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "s", "a":
+		add := msg.String() == "a"
+		label := "New border size: <blocks> [seconds]"
+		if add {
+			label = "Grow (negative shrinks) by: <blocks> [seconds]"
+		}
+		m.AskPrompt(label, "1000 60", func(m *Model, value string) {
+			size, seconds, err := parseSizeAndTime(value)
+			if err != nil {
+				m.err = err
+				return
+			}
+
+			target := size
+			cmd := "worldborder set "
+			if add {
+				target = m.worldborder.border.Size + size
+				cmd = "worldborder add "
+			}
+			cmd += strings.Join(strings.Fields(value), " ")
+
+			text := fmt.Sprintf("Change border from %.0f to %.0f blocks", m.worldborder.border.Size, target)
+			if seconds > 0 {
+				text += " over " + formatCountdown(time.Duration(seconds*float64(time.Second)))
+			}
+			m.AskConfirm(text+"?", []string{cmd}, func(m *Model, resps []string) {
+				if mc.ClassifyResponse(resps[0]) == nil {
+					m.startBorderMove(target, seconds)
+				}
+				refreshPanel(m, nil)
+			})
+		})
+
+	case "c":
+		m.AskPrompt("Border center: <x> <z>", "0 0", func(m *Model, value string) {
+			parts := strings.Fields(value)
+			if len(parts) != 2 {
+				m.err = fmt.Errorf("expected <x> <z>, got %q", value)
+				return
+			}
+			x, errX := strconv.ParseFloat(parts[0], 64)
+			z, errZ := strconv.ParseFloat(parts[1], 64)
+			if errX != nil || errZ != nil {
+				m.err = fmt.Errorf("invalid center %q", value)
+				return
+			}
+			m.AskConfirm(fmt.Sprintf("Move border center to %.1f, %.1f?", x, z), []string{"worldborder center " + parts[0] + " " + parts[1]}, func(m *Model, resps []string) {
+				if mc.ClassifyResponse(resps[0]) == nil {
+					m.worldborder.border.CenterX, m.worldborder.border.CenterZ = x, z
+					m.worldborder.centerKnown = true
+				}
+			})
+		})
+
+	case "d", "b":
+		kind, label := "amount", "Damage per block outside the buffer"
+		if msg.String() == "b" {
+			kind, label = "buffer", "Safe zone distance in blocks"
+		}
+		m.AskPrompt(label, "0.2", func(m *Model, value string) {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil || v < 0 {
+				m.err = fmt.Errorf("invalid value %q", value)
+				return
+			}
+			m.RunCmds([]string{"worldborder damage " + kind + " " + value}, func(m *Model, resps []string) {
+				if mc.ClassifyResponse(resps[0]) == nil {
+					if kind == "amount" {
+						m.worldborder.border.DamagePerBlock = v
+					} else {
+						m.worldborder.border.SafeZone = v
+					}
+				}
+			})
+		})
+
+	case "r":
+		m.worldborder.playersFetched = time.Time{}
+		refreshPanel(m, nil)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	s := int(d.Round(time.Second).Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// borderDiagram draws a top-down view of the border with the players. A
+// terminal cell is about twice as tall as wide, so every row covers two
+// columns worth of blocks.
+func (m Model) borderDiagram(rows int) string {
+	cols := rows * 2
+	b := m.worldborder.border
+
+	half := b.Size / 2
+	extent := half * 1.2
+	for _, p := range m.worldborder.players {
+		d := math.Max(math.Abs(p.x-b.CenterX), math.Abs(p.z-b.CenterZ)) * 1.1
+		extent = math.Max(extent, math.Min(d, half*4))
+	}
+	if extent <= 0 {
+		extent = 1
+	}
+
+	toCol := func(x float64) int {
+		return int(math.Round((x - b.CenterX + extent) / (2 * extent) * float64(cols-1)))
+	}
+	toRow := func(z float64) int {
+		return int(math.Round((z - b.CenterZ + extent) / (2 * extent) * float64(rows-1)))
+	}
+
+	grid := make([][]string, rows)
+	for r := range grid {
+		grid[r] = make([]string, cols)
+		for c := range grid[r] {
+			grid[r][c] = " "
+		}
+	}
+	set := func(r, c int, s string) {
+		if r >= 0 && r < rows && c >= 0 && c < cols {
+			grid[r][c] = s
+		}
+	}
+
+	rect := func(size float64, h, v, corners string, style lipgloss.Style) {
+		x0, x1 := toCol(b.CenterX-size/2), toCol(b.CenterX+size/2)
+		z0, z1 := toRow(b.CenterZ-size/2), toRow(b.CenterZ+size/2)
+		for c := x0; c <= x1; c++ {
+			set(z0, c, style.Render(h))
+			set(z1, c, style.Render(h))
+		}
+		for r := z0; r <= z1; r++ {
+			set(r, x0, style.Render(v))
+			set(r, x1, style.Render(v))
+		}
+		cs := []rune(corners)
+		set(z0, x0, style.Render(string(cs[0])))
+		set(z0, x1, style.Render(string(cs[1])))
+		set(z1, x0, style.Render(string(cs[2])))
+		set(z1, x1, style.Render(string(cs[3])))
+	}
+
+	if m.borderMoving() {
+		rect(m.worldborder.moveTo, "·", "·", "····", lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.yellow)))
+	}
+	rect(b.Size, "─", "│", "┌┐└┘", lipgloss.NewStyle().Foreground(m.styles.borderColorActive))
+	set(toRow(b.CenterZ), toCol(b.CenterX), lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.textDimmed())).Render("+"))
+
+	for _, p := range m.worldborder.players {
+		color := m.colors.green
+		if borderDistance(b, p) < 0 {
+			color = m.colors.red
+		}
+		set(toRow(p.z), toCol(p.x), lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(color)).Render("@"))
+	}
+
+	lines := make([]string, rows)
+	for r := range grid {
+		lines[r] = strings.Join(grid[r], "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// borderDistance returns how far inside the border the player is, negative
+// values are outside.
+func borderDistance(b mc.WorldBorder, p borderPlayer) float64 {
+	return b.Size/2 - math.Max(math.Abs(p.x-b.CenterX), math.Abs(p.z-b.CenterZ))
+}
+
+func (m Model) borderMoving() bool {
+	wb := m.worldborder
+	return wb.moveDuration > 0 && time.Since(wb.moveStart) < wb.moveDuration
+}
+
+func (m Model) viewWorldBorderPanel(width, height int) string {
+	title := m.styles.playersTitle.Render("World border")
+
+	if !m.worldborder.loaded {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading...")
+	}
+
+	b := m.worldborder.border
+	label := lipgloss.NewStyle().Width(10).Foreground(lipgloss.Color(m.colors.textDimmed()))
+	value := lipgloss.NewStyle().Bold(true)
+	unknown := func(known bool, s string) string {
+		if known {
+			return s
+		}
+		return "?"
+	}
+
+	info := []string{
+		label.Render("Size:") + value.Render(fmt.Sprintf("%.0f", b.Size)),
+		label.Render("Center:") + value.Render(unknown(m.worldborder.centerKnown, fmt.Sprintf("%.1f, %.1f", b.CenterX, b.CenterZ))),
+		label.Render("Damage:") + value.Render(unknown(m.worldborder.fromFile || b.DamagePerBlock > 0, fmt.Sprintf("%.2f/block", b.DamagePerBlock))),
+		label.Render("Buffer:") + value.Render(unknown(m.worldborder.fromFile || b.SafeZone > 0, fmt.Sprintf("%.1f", b.SafeZone))),
+	}
+
+	if m.borderMoving() {
+		wb := m.worldborder
+		verb := "growing"
+		if wb.moveTo < wb.moveFrom {
+			verb = "shrinking"
+		}
+		left := wb.moveDuration - time.Since(wb.moveStart)
+		info = append(info, "",
+			lipgloss.NewStyle().Foreground(lipgloss.Color(m.colors.yellow)).Render(
+				fmt.Sprintf("%s to %.0f", verb, wb.moveTo),
+			),
+			value.Foreground(lipgloss.Color(m.colors.yellow)).Render(formatCountdown(left)),
+		)
+	}
+
+	if len(m.worldborder.players) > 0 {
+		info = append(info, "")
+		for _, p := range m.worldborder.players {
+			d := borderDistance(b, p)
+			color := m.colors.green
+			if d < 0 {
+				color = m.colors.red
+			}
+			name := p.name
+			if p.nether {
+				name += " (N)"
+			}
+			info = append(info, lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(fmt.Sprintf("@ %s %.0f", name, d)))
+		}
+	}
+
+	diagramRows := height - 4
+	if diagramRows*2 > width-24 {
+		diagramRows = (width - 24) / 2
+	}
+
+	body := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		lipgloss.NewStyle().MarginRight(2).Render(m.borderDiagram(diagramRows)),
+		lipgloss.NewStyle().Width(width-diagramRows*2-2).Render(lipgloss.JoinVertical(lipgloss.Top, info...)),
+	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		title,
+		m.styles.separator.Render(strings.Repeat("-", width)),
+		lipgloss.NewStyle().MaxHeight(height-3).Render(body),
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.colors.textDimmed())).
+			Render("[s] Size | [a] Grow/shrink | [c] Center | [d] Damage | [b] Buffer | [r] Refresh"),
+	)
+}